@@ -0,0 +1,287 @@
+// Package matrix exposes the engine as a Matrix appservice: each Subreddit
+// is puppeted as a room, each engine User as a ghost MXID, and room traffic
+// is translated into EngineActor messages.
+package matrix
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"maunium.net/go/mautrix/appservice"
+	"maunium.net/go/mautrix/event"
+	"maunium.net/go/mautrix/id"
+
+	"github.com/hemanth-19/secure-reddit-clone-go/actor-model/engine"
+)
+
+// roomAliasPrefix/userPrefix namespace every Matrix-side identity created by
+// the bridge, mirroring the engine 1:1 without colliding with real users.
+const (
+	roomAliasPrefix = "_srcgo__"
+	userPrefix      = "_srcgo__"
+)
+
+// Config holds the appservice registration and homeserver details the
+// bridge authenticates with.
+type Config struct {
+	RegistrationPath string
+	HomeserverDomain string
+	HomeserverURL    string
+}
+
+// mirroredAccountPassword is the fixed password the bridge registers
+// puppeted accounts with. These accounts have no real-world credential of
+// their own; the bridge is their only caller, so a shared password is enough
+// to satisfy the engine's login requirement without inventing per-user
+// secrets.
+const mirroredAccountPassword = "matrix-bridge-mirrored-account"
+
+// mirroredAccount is the engine identity a puppeted Matrix user was
+// registered and logged in as.
+type mirroredAccount struct {
+	userID int
+	token  string
+}
+
+// MatrixBridgeActor logs in as the appservice bot and mirrors Matrix room
+// traffic into engine subreddits. Engine activity is not sent back to Matrix.
+type MatrixBridgeActor struct {
+	cfg       Config
+	enginePID *actor.PID
+	as        *appservice.AppService
+
+	mu            sync.Mutex
+	accounts      map[string]mirroredAccount // engine username -> mirrored account
+	roomSubreddit map[id.RoomID]string       // room ID -> subreddit it puppets, resolved from the room's canonical alias
+	posts         map[id.RoomID]int          // room ID of a post's thread root -> engine PostID
+	postComments  map[id.RoomID]int          // room ID -> last comment index assigned within that post's thread
+	comments      map[id.EventID]int         // matrix event ID of a posted comment -> engine CommentID, for in_reply_to threading
+}
+
+// NewMatrixBridgeActor builds a bridge actor that forwards translated room
+// events to enginePID.
+func NewMatrixBridgeActor(cfg Config, enginePID *actor.PID) *MatrixBridgeActor {
+	return &MatrixBridgeActor{
+		cfg:           cfg,
+		enginePID:     enginePID,
+		accounts:      make(map[string]mirroredAccount),
+		roomSubreddit: make(map[id.RoomID]string),
+		posts:         make(map[id.RoomID]int),
+		postComments:  make(map[id.RoomID]int),
+		comments:      make(map[id.EventID]int),
+	}
+}
+
+func (a *MatrixBridgeActor) Receive(ctx actor.Context) {
+	switch ctx.Message().(type) {
+	case *actor.Started:
+		if err := a.start(ctx); err != nil {
+			log.Printf("matrix bridge: start: %v", err)
+		}
+	case *actor.Stopping:
+		if a.as != nil {
+			a.as.Stop()
+		}
+	}
+}
+
+func (a *MatrixBridgeActor) start(ctx actor.Context) error {
+	reg, err := appservice.LoadRegistration(a.cfg.RegistrationPath)
+	if err != nil {
+		return fmt.Errorf("load registration: %w", err)
+	}
+
+	as, err := appservice.CreateFull(appservice.CreateOpts{
+		Registration:     reg,
+		HomeserverDomain: a.cfg.HomeserverDomain,
+		HomeserverURL:    a.cfg.HomeserverURL,
+	})
+	if err != nil {
+		return fmt.Errorf("create appservice: %w", err)
+	}
+	a.as = as
+
+	processor := appservice.NewEventProcessor(as)
+	processor.On(event.EventMessage, func(reqCtx context.Context, evt *event.Event) { a.handleMessage(ctx, reqCtx, evt) })
+	processor.On(event.StateMember, func(reqCtx context.Context, evt *event.Event) { a.handleMembership(ctx, reqCtx, evt) })
+	go processor.Start(context.Background())
+
+	as.Ready = true
+	go as.Start()
+	return nil
+}
+
+// roomAlias returns the Matrix room alias puppeting subreddit.
+func (a *MatrixBridgeActor) roomAlias(subreddit string) string {
+	return fmt.Sprintf("#%s%s:%s", roomAliasPrefix, subreddit, a.cfg.HomeserverDomain)
+}
+
+// puppetMXID returns the ghost MXID puppeting an engine username.
+func (a *MatrixBridgeActor) puppetMXID(username string) id.UserID {
+	return id.NewUserID(userPrefix+username, a.cfg.HomeserverDomain)
+}
+
+func (a *MatrixBridgeActor) subredditFromAlias(alias string) (string, bool) {
+	local := strings.TrimPrefix(alias, "#")
+	local, _, _ = strings.Cut(local, ":")
+	name := strings.TrimPrefix(local, roomAliasPrefix)
+	if name == local {
+		return "", false
+	}
+	return name, true
+}
+
+// subredditForRoom resolves the subreddit a room puppets by looking up the
+// room's canonical alias, which is the opaque RoomID's only link back to the
+// #_srcgo__<sub> alias the bridge minted it under. Resolutions are cached
+// since the canonical alias of a room never changes once set.
+func (a *MatrixBridgeActor) subredditForRoom(ctx context.Context, roomID id.RoomID) (string, bool) {
+	a.mu.Lock()
+	if subreddit, ok := a.roomSubreddit[roomID]; ok {
+		a.mu.Unlock()
+		return subreddit, true
+	}
+	a.mu.Unlock()
+
+	var aliasContent event.CanonicalAliasEventContent
+	if err := a.as.BotClient().StateEvent(ctx, roomID, event.StateCanonicalAlias, "", &aliasContent); err != nil {
+		log.Printf("matrix bridge: resolve canonical alias for %s: %v", roomID, err)
+		return "", false
+	}
+	subreddit, ok := a.subredditFromAlias(string(aliasContent.Alias))
+	if !ok {
+		return "", false
+	}
+	a.mu.Lock()
+	a.roomSubreddit[roomID] = subreddit
+	a.mu.Unlock()
+	return subreddit, true
+}
+
+// mirrorAccount registers and logs in a Matrix sender with the engine on
+// first sight and caches the resulting session for subsequent events from
+// them.
+func (a *MatrixBridgeActor) mirrorAccount(ctx actor.Context, mxid id.UserID) mirroredAccount {
+	username := mxid.Localpart()
+	a.mu.Lock()
+	if acc, ok := a.accounts[username]; ok {
+		a.mu.Unlock()
+		return acc
+	}
+	a.mu.Unlock()
+
+	reply := make(chan string)
+	ctx.Send(a.enginePID, &engine.RegisterUser{Username: username, Password: mirroredAccountPassword, Reply: reply})
+	<-reply
+
+	loginReply := make(chan engine.LoginResult)
+	ctx.Send(a.enginePID, &engine.Login{Username: username, Password: mirroredAccountPassword, Reply: loginReply})
+	result := <-loginReply
+
+	acc := mirroredAccount{userID: result.UserID, token: result.Token}
+	a.mu.Lock()
+	a.accounts[username] = acc
+	a.mu.Unlock()
+	return acc
+}
+
+// handleMembership maps StateMember join/leave events onto
+// JoinSubreddit/LeaveSubreddit for the room's mirrored subreddit.
+func (a *MatrixBridgeActor) handleMembership(ctx actor.Context, reqCtx context.Context, evt *event.Event) {
+	subreddit, ok := a.subredditForRoom(reqCtx, evt.RoomID)
+	if !ok {
+		return
+	}
+	acc := a.mirrorAccount(ctx, evt.Sender)
+	reply := make(chan string)
+	content := evt.Content.AsMember()
+
+	switch content.Membership {
+	case event.MembershipJoin:
+		ctx.Send(a.enginePID, &engine.JoinSubreddit{SessionToken: acc.token, Subreddit: subreddit, Reply: reply})
+		<-reply
+	case event.MembershipLeave:
+		ctx.Send(a.enginePID, &engine.LeaveSubreddit{SessionToken: acc.token, Subreddit: subreddit, Reply: reply})
+		<-reply
+	}
+}
+
+// handleMessage maps a room message onto CreatePost/CommentOnPost/
+// ReplyToComment (or SendMessage for a direct-message room), following
+// m.in_reply_to threading: a reply to the post's own thread-root event
+// becomes a top-level comment, and a reply to an already-mirrored comment
+// becomes a ReplyToComment.
+func (a *MatrixBridgeActor) handleMessage(ctx actor.Context, reqCtx context.Context, evt *event.Event) {
+	content := evt.Content.AsMessage()
+	if content == nil {
+		return
+	}
+	acc := a.mirrorAccount(ctx, evt.Sender)
+
+	if subreddit, ok := a.subredditForRoom(reqCtx, evt.RoomID); ok {
+		a.mu.Lock()
+		postID, postKnown := a.posts[evt.RoomID]
+		a.mu.Unlock()
+
+		relatesTo := content.RelatesTo
+		if postKnown && relatesTo != nil && relatesTo.InReplyTo != nil {
+			a.mu.Lock()
+			commentID, commentKnown := a.comments[relatesTo.InReplyTo.EventID]
+			a.mu.Unlock()
+			if commentKnown {
+				reply := make(chan string)
+				ctx.Send(a.enginePID, &engine.ReplyToComment{PostID: postID, CommentID: commentID, SessionToken: acc.token, Content: content.Body, Reply: reply})
+				<-reply
+				return
+			}
+
+			reply := make(chan string)
+			ctx.Send(a.enginePID, &engine.CommentOnPost{PostID: postID, SessionToken: acc.token, Content: content.Body, Reply: reply})
+			<-reply
+			a.mu.Lock()
+			a.postComments[evt.RoomID]++
+			a.comments[evt.ID] = a.postComments[evt.RoomID]
+			a.mu.Unlock()
+			return
+		}
+
+		reply := make(chan engine.CreatePostResult)
+		ctx.Send(a.enginePID, &engine.CreatePost{SessionToken: acc.token, Subreddit: subreddit, Content: content.Body, Reply: reply})
+		result := <-reply
+		if result.ID != 0 {
+			a.mu.Lock()
+			a.posts[evt.RoomID] = result.ID
+			a.mu.Unlock()
+		}
+		return
+	}
+
+	// Not a subreddit room: treat it as a direct message to the other
+	// member of the room.
+	receiverMXID, ok := a.otherMember(reqCtx, evt)
+	if !ok {
+		return
+	}
+	receiverAcc := a.mirrorAccount(ctx, receiverMXID)
+	reply := make(chan string)
+	ctx.Send(a.enginePID, &engine.SendMessage{SessionToken: acc.token, ReceiverID: receiverAcc.userID, Content: content.Body, Reply: reply})
+	<-reply
+}
+
+func (a *MatrixBridgeActor) otherMember(ctx context.Context, evt *event.Event) (id.UserID, bool) {
+	members, err := a.as.BotClient().JoinedMembers(ctx, evt.RoomID)
+	if err != nil {
+		log.Printf("matrix bridge: list joined members of %s: %v", evt.RoomID, err)
+		return "", false
+	}
+	for mxid := range members.Joined {
+		if mxid != evt.Sender && mxid != a.as.BotClient().UserID {
+			return mxid, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,222 @@
+// Package reddit bridges real Reddit activity into the engine by running a
+// graw bot and translating its event streams into EngineActor messages.
+package reddit
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/asynkron/protoactor-go/actor"
+	grawreddit "github.com/turnage/graw/reddit"
+	"github.com/turnage/graw/streams"
+
+	"github.com/hemanth-19/secure-reddit-clone-go/actor-model/engine"
+)
+
+// BotConfig holds the Reddit API credentials the bridge authenticates with,
+// sourced from CLI flags or environment variables by the caller.
+type BotConfig struct {
+	AppID    string
+	Secret   string
+	Username string
+	Password string
+}
+
+// BridgeError is forwarded to the supervisor PID when the underlying graw
+// stream fails.
+type BridgeError struct {
+	Source string
+	Err    error
+}
+
+// RedditBridgeActor mirrors r/<name> activity 1:1 into the engine: incoming
+// posts become CreatePost, replies to posts become CommentOnPost, and
+// replies to comments become ReplyToComment, all sent to EngineActor.
+type RedditBridgeActor struct {
+	cfg        BotConfig
+	enginePID  *actor.PID
+	supervisor *actor.PID
+	kill       chan bool
+
+	sessions map[string]string // Reddit username -> mirrored engine session token
+	ids      *idMirror
+}
+
+// mirroredAccountPassword is the fixed password the bridge registers mirrored
+// accounts with. These accounts have no real-world credential of their own;
+// the bridge is their only caller, so a shared password is enough to satisfy
+// the engine's login requirement without inventing per-user secrets.
+const mirroredAccountPassword = "reddit-bridge-mirrored-account"
+
+// NewRedditBridgeActor builds a bridge actor that forwards translated
+// messages to enginePID and reports stream errors to supervisorPID.
+func NewRedditBridgeActor(cfg BotConfig, enginePID, supervisorPID *actor.PID) *RedditBridgeActor {
+	return &RedditBridgeActor{
+		cfg:        cfg,
+		enginePID:  enginePID,
+		supervisor: supervisorPID,
+		kill:       make(chan bool),
+		sessions:   make(map[string]string),
+		ids:        newIDMirror(),
+	}
+}
+
+func (a *RedditBridgeActor) Receive(ctx actor.Context) {
+	switch ctx.Message().(type) {
+	case *actor.Started:
+		go a.run(ctx)
+	case *actor.Stopping:
+		close(a.kill)
+	}
+}
+
+func (a *RedditBridgeActor) run(ctx actor.Context) {
+	bot, err := grawreddit.NewBot(grawreddit.BotConfig{
+		Agent: fmt.Sprintf("secure-reddit-clone-go bridge v0.1 by /u/%s", a.cfg.Username),
+		App: grawreddit.App{
+			ID:       a.cfg.AppID,
+			Secret:   a.cfg.Secret,
+			Username: a.cfg.Username,
+			Password: a.cfg.Password,
+		},
+	})
+	if err != nil {
+		ctx.Send(a.supervisor, &BridgeError{Source: "reddit", Err: fmt.Errorf("create bot: %w", err)})
+		return
+	}
+
+	postErrs := make(chan error)
+	posts, userComments, err := streams.User(bot, a.kill, postErrs, a.cfg.Username)
+	if err != nil {
+		ctx.Send(a.supervisor, &BridgeError{Source: "reddit", Err: fmt.Errorf("stream user activity: %w", err)})
+		return
+	}
+	postReplyErrs := make(chan error)
+	postReplies, err := streams.PostReplies(bot, a.kill, postReplyErrs)
+	if err != nil {
+		ctx.Send(a.supervisor, &BridgeError{Source: "reddit", Err: fmt.Errorf("stream post replies: %w", err)})
+		return
+	}
+	commentReplyErrs := make(chan error)
+	commentReplies, err := streams.CommentReplies(bot, a.kill, commentReplyErrs)
+	if err != nil {
+		ctx.Send(a.supervisor, &BridgeError{Source: "reddit", Err: fmt.Errorf("stream comment replies: %w", err)})
+		return
+	}
+
+	for {
+		select {
+		case post, ok := <-posts:
+			if !ok {
+				return
+			}
+			a.handlePost(ctx, post)
+		case _, ok := <-userComments:
+			// The bot's own new top-level comments, which it never posts
+			// itself; nothing to mirror. Just keep draining so the stream
+			// doesn't block.
+			if !ok {
+				return
+			}
+		case reply, ok := <-postReplies:
+			if !ok {
+				return
+			}
+			a.handleComment(ctx, reply)
+		case reply, ok := <-commentReplies:
+			if !ok {
+				return
+			}
+			a.handleCommentReply(ctx, reply)
+		case err, ok := <-postErrs:
+			if !ok {
+				continue
+			}
+			ctx.Send(a.supervisor, &BridgeError{Source: "reddit", Err: fmt.Errorf("user stream: %w", err)})
+		case err, ok := <-postReplyErrs:
+			if !ok {
+				continue
+			}
+			ctx.Send(a.supervisor, &BridgeError{Source: "reddit", Err: fmt.Errorf("post replies stream: %w", err)})
+		case err, ok := <-commentReplyErrs:
+			if !ok {
+				continue
+			}
+			ctx.Send(a.supervisor, &BridgeError{Source: "reddit", Err: fmt.Errorf("comment replies stream: %w", err)})
+		case <-a.kill:
+			return
+		}
+	}
+}
+
+// mirrorSession maps a Reddit username onto an engine session token,
+// registering and logging the user in on first sight so the subreddit
+// namespace stays populated 1:1.
+func (a *RedditBridgeActor) mirrorSession(ctx actor.Context, username string) string {
+	if token, ok := a.sessions[username]; ok {
+		return token
+	}
+	reply := make(chan string)
+	ctx.Send(a.enginePID, &engine.RegisterUser{Username: username, Password: mirroredAccountPassword, Reply: reply})
+	<-reply
+
+	loginReply := make(chan engine.LoginResult)
+	ctx.Send(a.enginePID, &engine.Login{Username: username, Password: mirroredAccountPassword, Reply: loginReply})
+	result := <-loginReply
+	a.sessions[username] = result.Token
+	return result.Token
+}
+
+func (a *RedditBridgeActor) mirroredSubreddit(name string) string {
+	return "r/" + name
+}
+
+func (a *RedditBridgeActor) handlePost(ctx actor.Context, post *grawreddit.Post) {
+	token := a.mirrorSession(ctx, post.Author)
+	subreddit := a.mirroredSubreddit(post.Subreddit)
+	reply := make(chan string)
+	ctx.Send(a.enginePID, &engine.CreateSubreddit{SessionToken: token, Subreddit: subreddit, Reply: reply})
+	<-reply
+
+	postReply := make(chan engine.CreatePostResult)
+	ctx.Send(a.enginePID, &engine.CreatePost{SessionToken: token, Subreddit: subreddit, Content: post.SelfText, Reply: postReply})
+	result := <-postReply
+	if result.ID == 0 {
+		log.Printf("reddit bridge: mirrored post by %s: %s", post.Author, result.Message)
+		return
+	}
+	a.ids.mirrorPost(post.Name, result.ID)
+}
+
+// handleComment mirrors a top-level reply to one of the bot's own posts. The
+// stream only carries post replies, so the message's ParentID is always the
+// post's own "t3_…" fullname (Context is a permalink string, not a
+// fullname, and can never match what handlePost mirrored the post under).
+func (a *RedditBridgeActor) handleComment(ctx actor.Context, comment *grawreddit.Message) {
+	postID, err := a.ids.postID(comment.ParentID)
+	if err != nil {
+		log.Printf("reddit bridge: comment on unknown post: %v", err)
+		return
+	}
+	token := a.mirrorSession(ctx, comment.Author)
+	reply := make(chan string)
+	ctx.Send(a.enginePID, &engine.CommentOnPost{PostID: postID, SessionToken: token, Content: comment.Body, Reply: reply})
+	<-reply
+	a.ids.mirrorComment(comment.Name, postID)
+}
+
+// handleCommentReply mirrors a reply to one of the bot's own comments. The
+// message's ParentID is the parent comment's "t1_…" fullname, which
+// mirrorComment recorded alongside the post it belongs to, so both the
+// PostID and CommentID the engine needs come from that one lookup.
+func (a *RedditBridgeActor) handleCommentReply(ctx actor.Context, reply *grawreddit.Message) {
+	parent, err := a.ids.comment(reply.ParentID)
+	if err != nil {
+		log.Printf("reddit bridge: reply on unknown comment: %v", err)
+		return
+	}
+	token := a.mirrorSession(ctx, reply.Author)
+	out := make(chan string)
+	ctx.Send(a.enginePID, &engine.ReplyToComment{PostID: parent.postID, CommentID: parent.commentID, SessionToken: token, Content: reply.Body, Reply: out})
+	<-out
+}
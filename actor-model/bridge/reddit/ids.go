@@ -0,0 +1,59 @@
+package reddit
+
+import "fmt"
+
+// postIDs map a Reddit fullname (e.g. "t3_abc123") to the engine ID it was
+// mirrored as, so that later replies can be routed back to the right post.
+// comments maps a comment's fullname ("t1_def456") to both the engine
+// comment it was mirrored as and the post it belongs to, since a reply to a
+// comment only carries the parent comment's fullname and still needs a
+// PostID to address the engine. Engine comment IDs are 1-based indices into
+// their post's own Comments slice, not a global sequence, so postCommentSeq
+// tracks the next comment index per post rather than one counter shared
+// across every post.
+type idMirror struct {
+	posts          map[string]int
+	comments       map[string]mirroredComment
+	postCommentSeq map[int]int
+}
+
+// mirroredComment is the engine address of a Reddit comment the bridge has
+// already mirrored.
+type mirroredComment struct {
+	postID    int
+	commentID int
+}
+
+func newIDMirror() *idMirror {
+	return &idMirror{posts: map[string]int{}, comments: map[string]mirroredComment{}, postCommentSeq: map[int]int{}}
+}
+
+// mirrorPost records the engine's assigned postID for a Reddit fullname.
+func (m *idMirror) mirrorPost(fullname string, engineID int) {
+	m.posts[fullname] = engineID
+}
+
+// mirrorComment assigns the next per-post comment index for postID and
+// records it, along with the owning postID, against the Reddit fullname.
+func (m *idMirror) mirrorComment(fullname string, postID int) int {
+	m.postCommentSeq[postID]++
+	commentID := m.postCommentSeq[postID]
+	m.comments[fullname] = mirroredComment{postID: postID, commentID: commentID}
+	return commentID
+}
+
+func (m *idMirror) postID(fullname string) (int, error) {
+	id, ok := m.posts[fullname]
+	if !ok {
+		return 0, fmt.Errorf("no mirrored post for fullname %q", fullname)
+	}
+	return id, nil
+}
+
+func (m *idMirror) comment(fullname string) (mirroredComment, error) {
+	c, ok := m.comments[fullname]
+	if !ok {
+		return mirroredComment{}, fmt.Errorf("no mirrored comment for fullname %q", fullname)
+	}
+	return c, nil
+}
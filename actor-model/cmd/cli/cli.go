@@ -0,0 +1,174 @@
+// Package cli is the original numeric menu loop, kept as a fallback entry
+// point for scripts and terminals that can't host the Bubble Tea TUI.
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	"github.com/hemanth-19/secure-reddit-clone-go/actor-model/engine"
+)
+
+// Run drives the blocking numeric menu loop against enginePID until the user
+// picks "Exit". The caller must log in (registering first if needed) before
+// reaching the main menu; every mutating action afterwards is authenticated
+// with the session token obtained at login.
+func Run(root *actor.RootContext, enginePID *actor.PID) {
+	fmt.Println("\033[1;36mWelcome to ProtoActor-based Social Media Platform!\033[0m")
+
+	token := login(root, enginePID)
+
+	for {
+		fmt.Println("\nChoose an option:")
+		fmt.Println("1. Create Subreddit")
+		fmt.Println("2. Join Subreddit")
+		fmt.Println("3. Create Post")
+		fmt.Println("4. Comment on Post")
+		fmt.Println("5. Reply to Comment")
+		fmt.Println("6. Like Post")
+		fmt.Println("7. Dislike Post")
+		fmt.Println("8. Leave Subreddit")
+		fmt.Println("9. Send Message")
+		fmt.Println("10. Reply to Message")
+		fmt.Println("11. View Inbox")
+		fmt.Println("12. View Ranked Feed")
+		fmt.Println("13. Exit")
+
+		choice := readInput("Enter your choice: ")
+		switch choice {
+		case "1":
+			subreddit := readInput("Enter subreddit name: ")
+			reply := make(chan string)
+			root.Send(enginePID, &engine.CreateSubreddit{SessionToken: token, Subreddit: subreddit, Reply: reply})
+			fmt.Println(<-reply)
+		case "2":
+			subreddit := readInput("Enter subreddit name: ")
+			reply := make(chan string)
+			root.Send(enginePID, &engine.JoinSubreddit{SessionToken: token, Subreddit: subreddit, Reply: reply})
+			fmt.Println(<-reply)
+		case "3":
+			subreddit := readInput("Enter subreddit name: ")
+			content := readInput("Enter post content: ")
+			reply := make(chan engine.CreatePostResult)
+			root.Send(enginePID, &engine.CreatePost{SessionToken: token, Subreddit: subreddit, Content: content, Reply: reply})
+			result := <-reply
+			fmt.Println(result.Message)
+		case "4":
+			postID, _ := strconv.Atoi(readInput("Enter post ID: "))
+			content := readInput("Enter comment content: ")
+			reply := make(chan string)
+			root.Send(enginePID, &engine.CommentOnPost{PostID: postID, SessionToken: token, Content: content, Reply: reply})
+			fmt.Println(<-reply)
+		case "5":
+			postID, _ := strconv.Atoi(readInput("Enter post ID: "))
+			commentID, _ := strconv.Atoi(readInput("Enter comment ID to reply to: "))
+			content := readInput("Enter reply content: ")
+			reply := make(chan string)
+			root.Send(enginePID, &engine.ReplyToComment{PostID: postID, CommentID: commentID, SessionToken: token, Content: content, Reply: reply})
+			fmt.Println(<-reply)
+		case "6":
+			postID, _ := strconv.Atoi(readInput("Enter post ID to like: "))
+			reply := make(chan string)
+			root.Send(enginePID, &engine.LikePost{PostID: postID, SessionToken: token, Reply: reply})
+			fmt.Println(<-reply)
+		case "7":
+			postID, _ := strconv.Atoi(readInput("Enter post ID to dislike: "))
+			reply := make(chan string)
+			root.Send(enginePID, &engine.DislikePost{PostID: postID, SessionToken: token, Reply: reply})
+			fmt.Println(<-reply)
+		case "8":
+			subreddit := readInput("Enter subreddit name: ")
+			reply := make(chan string)
+			root.Send(enginePID, &engine.LeaveSubreddit{SessionToken: token, Subreddit: subreddit, Reply: reply})
+			fmt.Println(<-reply)
+		case "9":
+			receiverID, _ := strconv.Atoi(readInput("Enter receiver ID: "))
+			content := readInput("Enter message content: ")
+			reply := make(chan string)
+			root.Send(enginePID, &engine.SendMessage{SessionToken: token, ReceiverID: receiverID, Content: content, Reply: reply})
+			fmt.Println(<-reply)
+		case "10":
+			receiverID, _ := strconv.Atoi(readInput("Enter receiver ID: "))
+			content := readInput("Enter reply content: ")
+			reply := make(chan string)
+			root.Send(enginePID, &engine.ReplyToMessage{SessionToken: token, ReceiverID: receiverID, Content: content, Reply: reply})
+			fmt.Println(<-reply)
+		case "11":
+			reply := make(chan string)
+			root.Send(enginePID, &engine.ViewInbox{SessionToken: token, Reply: reply})
+			fmt.Println(<-reply)
+		case "12":
+			subreddit := readInput("Enter subreddit name: ")
+			mode := parseRankMode(readInput("Mode (hot/top/new): "))
+			reply := make(chan []*engine.Post)
+			root.Send(enginePID, &engine.RankFeed{Subreddit: subreddit, Mode: mode, Reply: reply})
+			posts := <-reply
+			if len(posts) == 0 {
+				fmt.Println("\033[1;31mNo posts found.\033[0m")
+				continue
+			}
+			for _, post := range posts {
+				fmt.Printf("#%d [+%d/-%d] %s\n", post.ID, post.Upvotes, post.Downvotes, post.Content)
+			}
+		case "13":
+			reply := make(chan string)
+			root.Send(enginePID, &engine.Logout{SessionToken: token, Reply: reply})
+			<-reply
+			fmt.Println("\033[1;31mExiting... Goodbye!\033[0m")
+			return
+		default:
+			fmt.Println("\033[1;31mInvalid choice. Please try again.\033[0m")
+		}
+	}
+}
+
+// login prompts for an existing account, registering one first if the user
+// asks to, and returns the session token to authenticate every subsequent
+// request with.
+func login(root *actor.RootContext, enginePID *actor.PID) string {
+	for {
+		if readInput("Register a new account first? (y/N): ") == "y" {
+			username := readInput("Choose a username: ")
+			password := readInput("Choose a password: ")
+			reply := make(chan string)
+			root.Send(enginePID, &engine.RegisterUser{Username: username, Password: password, Reply: reply})
+			fmt.Println(<-reply)
+		}
+
+		username := readInput("Username: ")
+		password := readInput("Password: ")
+		reply := make(chan engine.LoginResult)
+		root.Send(enginePID, &engine.Login{Username: username, Password: password, Reply: reply})
+		result := <-reply
+		fmt.Println(result.Message)
+		if result.Token != "" {
+			return result.Token
+		}
+	}
+}
+
+// parseRankMode maps a free-typed mode name to a RankMode, defaulting to
+// RankHot for anything unrecognized.
+func parseRankMode(text string) engine.RankMode {
+	switch text {
+	case "top":
+		return engine.RankTop
+	case "new":
+		return engine.RankNew
+	default:
+		return engine.RankHot
+	}
+}
+
+// readInput prints prompt and reads one trimmed line from stdin.
+func readInput(prompt string) string {
+	fmt.Print(prompt)
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Scan()
+	return strings.TrimSpace(scanner.Text())
+}
@@ -0,0 +1,195 @@
+// Command simulate load-tests an in-memory engine with Zipf-distributed
+// users and subreddits, mimicking the skewed traffic of a real community
+// (a handful of hot users and subreddits dominate), and reports throughput
+// and latency percentiles for the mixed posting/voting workload.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	"github.com/hemanth-19/secure-reddit-clone-go/actor-model/engine"
+)
+
+func main() {
+	users := flag.Int("users", 500, "number of simulated users to register")
+	subreddits := flag.Int("subreddits", 25, "number of simulated subreddits to create")
+	concurrency := flag.Int("concurrency", 50, "number of concurrent simulated clients")
+	duration := flag.Duration("duration", 10*time.Second, "how long to run the simulation")
+	zipfS := flag.Float64("zipf-s", 1.5, "Zipf distribution skew parameter (>1); higher means a smaller set of hot users/subreddits dominates traffic")
+	voteRatio := flag.Float64("vote-ratio", 0.7, "fraction of operations that vote on an existing post instead of creating a new one")
+	flag.Parse()
+
+	root, enginePID := bootstrapEngine()
+
+	adminToken := registerAndLogin(root, enginePID, "sim-admin")
+	subredditNames := make([]string, *subreddits)
+	for i := range subredditNames {
+		subredditNames[i] = fmt.Sprintf("sub%d", i)
+		reply := make(chan string, 1)
+		root.Send(enginePID, &engine.CreateSubreddit{SessionToken: adminToken, Subreddit: subredditNames[i], Reply: reply})
+		<-reply
+	}
+
+	tokens := make([]string, *users)
+	for i := range tokens {
+		tokens[i] = registerAndLogin(root, enginePID, fmt.Sprintf("sim-user-%d", i))
+	}
+
+	fmt.Printf("simulating %d users across %d subreddits with %d concurrent clients for %s (zipf-s=%.2f)\n",
+		*users, *subreddits, *concurrency, *duration, *zipfS)
+
+	var knownPosts []int
+	var postsMu sync.Mutex
+	stopRefresh := make(chan struct{})
+	var refreshWG sync.WaitGroup
+	refreshWG.Add(1)
+	go func() {
+		defer refreshWG.Done()
+		ticker := time.NewTicker(200 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopRefresh:
+				return
+			case <-ticker.C:
+				refreshKnownPosts(root, enginePID, subredditNames, &postsMu, &knownPosts)
+			}
+		}
+	}()
+
+	userZipf := rand.NewZipf(rand.New(rand.NewSource(1)), *zipfS, 1, uint64(*users-1))
+	subredditZipf := rand.NewZipf(rand.New(rand.NewSource(2)), *zipfS, 1, uint64(*subreddits-1))
+
+	var totalOps int64
+	deadline := time.Now().Add(*duration)
+	latencies := make(chan []time.Duration, *concurrency)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < *concurrency; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(int64(worker) + 1000))
+			var recorded []time.Duration
+			for time.Now().Before(deadline) {
+				token := tokens[userZipf.Uint64()]
+				subreddit := subredditNames[subredditZipf.Uint64()]
+
+				start := time.Now()
+				if rng.Float64() < *voteRatio {
+					postID, ok := randomKnownPost(&postsMu, &knownPosts, rng)
+					if !ok {
+						continue
+					}
+					reply := make(chan string, 1)
+					if rng.Float64() < 0.8 {
+						root.Send(enginePID, &engine.LikePost{PostID: postID, SessionToken: token, Reply: reply})
+					} else {
+						root.Send(enginePID, &engine.DislikePost{PostID: postID, SessionToken: token, Reply: reply})
+					}
+					<-reply
+				} else {
+					reply := make(chan engine.CreatePostResult, 1)
+					root.Send(enginePID, &engine.CreatePost{SessionToken: token, Subreddit: subreddit, Content: "simulated post", Reply: reply})
+					if result := <-reply; result.ID != 0 {
+						postsMu.Lock()
+						knownPosts = append(knownPosts, result.ID)
+						postsMu.Unlock()
+					}
+				}
+				recorded = append(recorded, time.Since(start))
+				atomic.AddInt64(&totalOps, 1)
+			}
+			latencies <- recorded
+		}(worker)
+	}
+
+	wg.Wait()
+	close(stopRefresh)
+	refreshWG.Wait()
+	close(latencies)
+
+	var all []time.Duration
+	for batch := range latencies {
+		all = append(all, batch...)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i] < all[j] })
+
+	fmt.Printf("ops=%d elapsed=%s throughput=%.1f ops/sec\n", totalOps, *duration, float64(totalOps)/duration.Seconds())
+	fmt.Printf("latency p50=%s p90=%s p99=%s max=%s\n",
+		percentile(all, 0.50), percentile(all, 0.90), percentile(all, 0.99), percentile(all, 1))
+}
+
+// bootstrapEngine spawns a fresh, unpersisted EngineActor to simulate
+// against, the same way the benchmarks do.
+func bootstrapEngine() (*actor.RootContext, *actor.PID) {
+	system := actor.NewActorSystem()
+	engineActor := engine.NewEngineActor(nil, engine.State{})
+	pid := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engineActor }))
+	return system.Root, pid
+}
+
+// registerAndLogin registers username with a fixed simulated password and
+// logs it in, returning the session token every subsequent call needs.
+func registerAndLogin(root *actor.RootContext, enginePID *actor.PID, username string) string {
+	const password = "simulate-password"
+
+	reply := make(chan string, 1)
+	root.Send(enginePID, &engine.RegisterUser{Username: username, Password: password, Reply: reply})
+	<-reply
+
+	loginReply := make(chan engine.LoginResult, 1)
+	root.Send(enginePID, &engine.Login{Username: username, Password: password, Reply: loginReply})
+	result := <-loginReply
+	if result.Token == "" {
+		panic(fmt.Sprintf("login %q: %s", username, result.Message))
+	}
+	return result.Token
+}
+
+// refreshKnownPosts repopulates knownPosts with every post ID that currently
+// exists, so voting workers also have targets among posts they did not
+// create themselves.
+func refreshKnownPosts(root *actor.RootContext, enginePID *actor.PID, subredditNames []string, mu *sync.Mutex, knownPosts *[]int) {
+	var ids []int
+	for _, name := range subredditNames {
+		reply := make(chan *engine.Subreddit, 1)
+		root.Send(enginePID, &engine.ViewSubreddit{Subreddit: name, Reply: reply})
+		subreddit := <-reply
+		if subreddit == nil {
+			continue
+		}
+		for _, post := range subreddit.Posts {
+			ids = append(ids, post.ID)
+		}
+	}
+	mu.Lock()
+	*knownPosts = ids
+	mu.Unlock()
+}
+
+func randomKnownPost(mu *sync.Mutex, knownPosts *[]int, rng *rand.Rand) (int, bool) {
+	mu.Lock()
+	defer mu.Unlock()
+	if len(*knownPosts) == 0 {
+		return 0, false
+	}
+	return (*knownPosts)[rng.Intn(len(*knownPosts))], true
+}
+
+// percentile returns the p-th percentile (0..1) of sorted, or 0 if empty.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// newBenchEngine spawns a fresh, unpersisted EngineActor for benchmarking.
+func newBenchEngine(b *testing.B) (*actor.RootContext, *actor.PID) {
+	b.Helper()
+	system := actor.NewActorSystem()
+	engineActor := NewEngineActor(nil, State{})
+	pid := system.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return engineActor }))
+	return system.Root, pid
+}
+
+// registerAndLogin registers username and logs it in, returning the session
+// token every mutating call in the benchmark authenticates with.
+func registerAndLogin(b *testing.B, root *actor.RootContext, enginePID *actor.PID, username string) string {
+	b.Helper()
+	const password = "bench-password"
+
+	reply := make(chan string, 1)
+	root.Send(enginePID, &RegisterUser{Username: username, Password: password, Reply: reply})
+	<-reply
+
+	loginReply := make(chan LoginResult, 1)
+	root.Send(enginePID, &Login{Username: username, Password: password, Reply: loginReply})
+	result := <-loginReply
+	if result.Token == "" {
+		b.Fatalf("login %q: %s", username, result.Message)
+	}
+	return result.Token
+}
+
+// BenchmarkConcurrentClients measures throughput and latency of CreatePost
+// traffic from many concurrent "clients" spread
+// across many subreddits. EngineActor itself still processes one message at
+// a time, so this does not show per-client throughput scaling; what
+// sharding posts/users onto their own actors buys is that EngineActor's own
+// work per message stays small and constant regardless of how much state
+// exists, instead of a single mutex serializing access to all of it too.
+func BenchmarkConcurrentClients(b *testing.B) {
+	const subreddits = 8
+	const usersPerSubreddit = 4
+
+	root, enginePID := newBenchEngine(b)
+
+	adminToken := registerAndLogin(b, root, enginePID, "admin")
+	for i := 0; i < subreddits; i++ {
+		reply := make(chan string, 1)
+		root.Send(enginePID, &CreateSubreddit{SessionToken: adminToken, Subreddit: fmt.Sprintf("sub%d", i), Reply: reply})
+		<-reply
+	}
+
+	tokens := make([]string, 0, subreddits*usersPerSubreddit)
+	for i := 0; i < subreddits*usersPerSubreddit; i++ {
+		tokens = append(tokens, registerAndLogin(b, root, enginePID, fmt.Sprintf("user%d", i)))
+	}
+
+	b.ResetTimer()
+	b.SetParallelism(usersPerSubreddit * subreddits)
+	b.RunParallel(func(pb *testing.PB) {
+		var n int
+		for pb.Next() {
+			subreddit := fmt.Sprintf("sub%d", n%subreddits)
+			token := tokens[n%len(tokens)]
+
+			reply := make(chan CreatePostResult, 1)
+			root.Send(enginePID, &CreatePost{SessionToken: token, Subreddit: subreddit, Content: "hello", Reply: reply})
+			<-reply
+
+			n++
+		}
+	})
+}
+
+// BenchmarkConcurrentClientsFixedConcurrency reports ns/op at a few fixed
+// client counts. Because EngineActor is a single supervisor that handles
+// one message at a time, ns/op is expected to stay roughly flat as client
+// count grows rather than improve with it; this is here to catch a
+// regression that makes the flat case worse, not to demonstrate scaling.
+func BenchmarkConcurrentClientsFixedConcurrency(b *testing.B) {
+	for _, clients := range []int{1, 8, 64, 256} {
+		clients := clients
+		b.Run(fmt.Sprintf("clients=%d", clients), func(b *testing.B) {
+			root, enginePID := newBenchEngine(b)
+			token := registerAndLogin(b, root, enginePID, "bench-user")
+			reply := make(chan string, 1)
+			root.Send(enginePID, &CreateSubreddit{SessionToken: token, Subreddit: "bench", Reply: reply})
+			<-reply
+
+			b.ResetTimer()
+			var wg sync.WaitGroup
+			perClient := b.N / clients
+			if perClient == 0 {
+				perClient = 1
+			}
+			for c := 0; c < clients; c++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < perClient; i++ {
+						reply := make(chan CreatePostResult, 1)
+						root.Send(enginePID, &CreatePost{SessionToken: token, Subreddit: "bench", Content: "hello", Reply: reply})
+						<-reply
+					}
+				}()
+			}
+			wg.Wait()
+		})
+	}
+}
@@ -0,0 +1,702 @@
+// Package engine implements the core ProtoActor-based social platform.
+// EngineActor is a supervisor that spawns one SubredditActor per subreddit
+// and one UserActor per user, routing messages to the actor that owns the
+// relevant state instead of serializing every operation behind one mutex.
+// A PostIndexActor owns the postID -> subreddit name mapping so comment and
+// reply lookups don't have to scan every post in every subreddit.
+package engine
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// SnapshotInterval controls how often the background snapshotter flushes
+// engine state to the store.
+const SnapshotInterval = 30 * time.Second
+
+const requestTimeout = 5 * time.Second
+
+const invalidSessionMsg = "\033[1;31mInvalid or expired session. Please log in again.\033[0m"
+
+// Messages
+type RegisterUser struct {
+	Username string
+	Password string
+	Reply    chan string
+}
+
+// Login authenticates a registered user and, on success, mints a session
+// token. Every mutating message below takes that token instead of a raw
+// UserID, so a client can no longer act as an arbitrary user just by
+// guessing an ID.
+type Login struct {
+	Username string
+	Password string
+	Reply    chan LoginResult
+}
+
+// LoginResult is Login's response: Token is empty on failure, in which case
+// Message explains why.
+type LoginResult struct {
+	Token   string
+	UserID  int
+	Message string
+}
+
+type CreateSubreddit struct {
+	SessionToken string
+	Subreddit    string
+	Reply        chan string
+}
+type JoinSubreddit struct {
+	SessionToken string
+	Subreddit    string
+	Reply        chan string
+}
+type LeaveSubreddit struct {
+	SessionToken string
+	Subreddit    string
+	Reply        chan string
+}
+type CreatePost struct {
+	SessionToken string
+	Subreddit    string
+	Content      string
+	Reply        chan CreatePostResult
+}
+
+// CreatePostResult is CreatePost's response. ID is the engine-assigned post
+// ID, which callers need to reference the post afterwards (e.g. to comment
+// on it); it is zero on failure, in which case Message explains why.
+type CreatePostResult struct {
+	ID      int
+	Message string
+}
+type CommentOnPost struct {
+	PostID       int
+	SessionToken string
+	Content      string
+	Reply        chan string
+}
+type ReplyToComment struct {
+	PostID       int
+	CommentID    int
+	SessionToken string
+	Content      string
+	Reply        chan string
+}
+type LikePost struct {
+	PostID       int
+	SessionToken string
+	Reply        chan string
+}
+type DislikePost struct {
+	PostID       int
+	SessionToken string
+	Reply        chan string
+}
+type SendMessage struct {
+	SessionToken string
+	ReceiverID   int
+	Content      string
+	Reply        chan string
+}
+type ReplyToMessage struct {
+	SessionToken string
+	ReceiverID   int
+	Content      string
+	Reply        chan string
+}
+type ViewInbox struct {
+	SessionToken string
+	Reply        chan string
+}
+
+// Logout revokes SessionToken immediately, instead of waiting for it to
+// expire after SessionTTL.
+type Logout struct {
+	SessionToken string
+	Reply        chan string
+}
+
+// ListSubreddits reports every known subreddit name, sorted, so a client can
+// browse without already knowing what exists.
+type ListSubreddits struct {
+	Reply chan []string
+}
+
+// ViewSubreddit fetches a point-in-time copy of a subreddit, including its
+// posts and comments, for browsing.
+type ViewSubreddit struct {
+	Subreddit string
+	Reply     chan *Subreddit
+}
+
+// RankFeed returns a point-in-time copy of Subreddit's posts sorted
+// according to Mode.
+type RankFeed struct {
+	Subreddit string
+	Mode      RankMode
+	Reply     chan []*Post
+}
+
+// Entity states, also used as the on-disk snapshot shape.
+type User struct {
+	ID           int
+	Username     string
+	PasswordHash string
+	Karma        int
+	Inbox        []string
+}
+
+type Subreddit struct {
+	Name  string
+	Users map[int]bool
+	Posts []*Post
+}
+
+type Post struct {
+	ID        int
+	UserID    int
+	Subreddit string
+	Content   string
+	Comments  []string
+	CreatedAt time.Time
+	Upvotes   int
+	Downvotes int
+	Voters    map[int]int8 // userID -> +1 (upvoted) or -1 (downvoted), for dedup
+}
+
+// State is the full reconstructable state of the engine: what gets written
+// by Snapshot and read back by Replay.
+type State struct {
+	NextUserID int
+	NextPostID int
+	Users      map[int]*User
+	Subreddits map[string]*Subreddit
+}
+
+// EngineActor is the supervisor: it owns no post/comment/inbox state
+// itself, only the routing tables needed to dispatch to the actor that
+// does (one SubredditActor per subreddit, one UserActor per user, and the
+// shared PostIndexActor).
+type EngineActor struct {
+	initial State // populated from Replay, consumed on actor.Started
+
+	store            Store
+	subredditPIDs    map[string]*actor.PID
+	userPIDs         map[int]*actor.PID
+	usernames        map[int]string
+	userIDByUsername map[string]int
+	nextUserID       int
+	postIndexPID     *actor.PID
+	sessionPID       *actor.PID
+
+	// sessions caches sessionActor's resolutions so that resolveSession, which
+	// every mutating message calls, usually doesn't pay a blocking round trip
+	// to another actor. EngineActor is the only caller of createSession and
+	// revokeSession, so this cache can only go stale by TTL expiry, which a
+	// cache miss falls back to sessionActor to catch.
+	sessions map[string]sessionEntry
+}
+
+// NewEngineActor builds an EngineActor that bootstraps itself from initial
+// (as produced by Store.Replay) once spawned.
+func NewEngineActor(store Store, initial State) *EngineActor {
+	return &EngineActor{
+		initial:          initial,
+		store:            store,
+		subredditPIDs:    make(map[string]*actor.PID),
+		userPIDs:         make(map[int]*actor.PID),
+		usernames:        make(map[int]string),
+		userIDByUsername: make(map[string]int),
+		sessions:         make(map[string]sessionEntry),
+	}
+}
+
+func (state *EngineActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		state.bootstrap(ctx)
+
+	case *RegisterUser:
+		if _, taken := state.userIDByUsername[msg.Username]; taken {
+			msg.Reply <- fmt.Sprintf("\033[1;31mUsername '%s' is already taken.\033[0m", msg.Username)
+			return
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(msg.Password), bcrypt.DefaultCost)
+		if err != nil {
+			msg.Reply <- fmt.Sprintf("\033[1;31mFailed to register user: %v\033[0m", err)
+			return
+		}
+		state.nextUserID++
+		user := &User{ID: state.nextUserID, Username: msg.Username, PasswordHash: string(hash), Inbox: []string{}}
+		state.spawnUser(ctx, user)
+		if state.store != nil {
+			if err := state.store.SaveUser(user); err != nil {
+				log.Printf("store: save user %d: %v", user.ID, err)
+			}
+		}
+		msg.Reply <- fmt.Sprintf("\033[1;32mUser '%s' registered successfully with ID %d.\033[0m", msg.Username, user.ID)
+
+	case *Login:
+		state.handleLogin(ctx, msg)
+
+	case *CreateSubreddit:
+		if _, ok := state.resolveSession(ctx, msg.SessionToken); !ok {
+			msg.Reply <- invalidSessionMsg
+			return
+		}
+		if _, exists := state.subredditPIDs[msg.Subreddit]; exists {
+			msg.Reply <- fmt.Sprintf("\033[1;32mSubreddit '%s' already exists.\033[0m", msg.Subreddit)
+			return
+		}
+		state.spawnSubreddit(ctx, &Subreddit{Name: msg.Subreddit, Users: map[int]bool{}})
+		if state.store != nil {
+			if err := state.store.AppendSubreddit(msg.Subreddit); err != nil {
+				log.Printf("store: append subreddit %q: %v", msg.Subreddit, err)
+			}
+		}
+		msg.Reply <- fmt.Sprintf("\033[1;32mSubreddit '%s' created successfully.\033[0m", msg.Subreddit)
+
+	case *JoinSubreddit:
+		userID, ok := state.resolveSession(ctx, msg.SessionToken)
+		if !ok {
+			msg.Reply <- invalidSessionMsg
+			return
+		}
+		state.routeMembership(ctx, msg.Subreddit, userID, true, msg.Reply)
+
+	case *LeaveSubreddit:
+		userID, ok := state.resolveSession(ctx, msg.SessionToken)
+		if !ok {
+			msg.Reply <- invalidSessionMsg
+			return
+		}
+		state.routeMembership(ctx, msg.Subreddit, userID, false, msg.Reply)
+
+	case *CreatePost:
+		userID, ok := state.resolveSession(ctx, msg.SessionToken)
+		if !ok {
+			msg.Reply <- CreatePostResult{Message: invalidSessionMsg}
+			return
+		}
+		subredditPID, exists := state.subredditPIDs[msg.Subreddit]
+		if !exists {
+			msg.Reply <- CreatePostResult{Message: fmt.Sprintf("\033[1;31mSubreddit '%s' does not exist.\033[0m", msg.Subreddit)}
+			return
+		}
+		postID, err := state.assignPostID(ctx, msg.Subreddit)
+		if err != nil {
+			msg.Reply <- CreatePostResult{Message: fmt.Sprintf("\033[1;31mFailed to create post: %v\033[0m", err)}
+			return
+		}
+		ctx.Send(subredditPID, &createPostCmd{
+			PostID:   postID,
+			UserID:   userID,
+			Username: state.getUsername(userID),
+			Content:  msg.Content,
+			Reply:    msg.Reply,
+		})
+
+	case *CommentOnPost:
+		userID, ok := state.resolveSession(ctx, msg.SessionToken)
+		if !ok {
+			msg.Reply <- invalidSessionMsg
+			return
+		}
+		subredditPID, ok := state.subredditForPost(ctx, msg.PostID)
+		if !ok {
+			msg.Reply <- fmt.Sprintf("\033[1;31mPost ID '%d' not found.\033[0m", msg.PostID)
+			return
+		}
+		ctx.Send(subredditPID, &commentCmd{
+			PostID:   msg.PostID,
+			Username: state.getUsername(userID),
+			Content:  msg.Content,
+			Reply:    msg.Reply,
+		})
+
+	case *ReplyToComment:
+		userID, ok := state.resolveSession(ctx, msg.SessionToken)
+		if !ok {
+			msg.Reply <- invalidSessionMsg
+			return
+		}
+		subredditPID, ok := state.subredditForPost(ctx, msg.PostID)
+		if !ok {
+			msg.Reply <- fmt.Sprintf("\033[1;31mComment or Post not found.\033[0m")
+			return
+		}
+		ctx.Send(subredditPID, &replyCmd{
+			PostID:    msg.PostID,
+			CommentID: msg.CommentID,
+			Username:  state.getUsername(userID),
+			Content:   msg.Content,
+			Reply:     msg.Reply,
+		})
+
+	case *LikePost:
+		userID, ok := state.resolveSession(ctx, msg.SessionToken)
+		if !ok {
+			msg.Reply <- invalidSessionMsg
+			return
+		}
+		state.routeVote(ctx, msg.PostID, userID, true, msg.Reply)
+
+	case *DislikePost:
+		userID, ok := state.resolveSession(ctx, msg.SessionToken)
+		if !ok {
+			msg.Reply <- invalidSessionMsg
+			return
+		}
+		state.routeVote(ctx, msg.PostID, userID, false, msg.Reply)
+
+	case *SendMessage:
+		userID, ok := state.resolveSession(ctx, msg.SessionToken)
+		if !ok {
+			msg.Reply <- invalidSessionMsg
+			return
+		}
+		state.routeDelivery(ctx, msg.ReceiverID, "Message", state.getUsername(userID), msg.Content, msg.Reply)
+
+	case *ReplyToMessage:
+		userID, ok := state.resolveSession(ctx, msg.SessionToken)
+		if !ok {
+			msg.Reply <- invalidSessionMsg
+			return
+		}
+		state.routeDelivery(ctx, msg.ReceiverID, "Reply", state.getUsername(userID), msg.Content, msg.Reply)
+
+	case *ViewInbox:
+		userID, ok := state.resolveSession(ctx, msg.SessionToken)
+		if !ok {
+			msg.Reply <- invalidSessionMsg
+			return
+		}
+		userPID, exists := state.userPIDs[userID]
+		if !exists {
+			msg.Reply <- fmt.Sprintf("\033[1;31mUser with ID %d does not exist.\033[0m", userID)
+			return
+		}
+		ctx.Send(userPID, &viewInboxCmd{Reply: msg.Reply})
+
+	case *Logout:
+		delete(state.sessions, msg.SessionToken)
+		ctx.Send(state.sessionPID, &revokeSession{Token: msg.SessionToken})
+		msg.Reply <- "\033[1;32mLogged out.\033[0m"
+
+	case *ListSubreddits:
+		names := make([]string, 0, len(state.subredditPIDs))
+		for name := range state.subredditPIDs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		msg.Reply <- names
+
+	case *ViewSubreddit:
+		subredditPID, exists := state.subredditPIDs[msg.Subreddit]
+		if !exists {
+			msg.Reply <- nil
+			return
+		}
+		future := ctx.RequestFuture(subredditPID, &querySubredditState{}, requestTimeout)
+		res, err := future.Result()
+		if err != nil {
+			msg.Reply <- nil
+			return
+		}
+		msg.Reply <- res.(*Subreddit)
+
+	case *RankFeed:
+		subredditPID, exists := state.subredditPIDs[msg.Subreddit]
+		if !exists {
+			msg.Reply <- nil
+			return
+		}
+		future := ctx.RequestFuture(subredditPID, &querySubredditState{}, requestTimeout)
+		res, err := future.Result()
+		if err != nil {
+			msg.Reply <- nil
+			return
+		}
+		msg.Reply <- rankPosts(res.(*Subreddit).Posts, msg.Mode)
+
+	case *SnapshotRequest:
+		state.handleSnapshotRequest(ctx, msg)
+	}
+}
+
+// getUsername resolves a userID to the name it registered with. Only
+// EngineActor's own goroutine reads/writes state.usernames, so no locking
+// is needed.
+func (state *EngineActor) getUsername(userID int) string {
+	if name, ok := state.usernames[userID]; ok {
+		return name
+	}
+	return fmt.Sprintf("User%d", userID)
+}
+
+// resolveSession reports whether token is still valid and, if so, which
+// userID it was issued to, serving from state.sessions whenever possible so
+// the common case doesn't pay a blocking round trip to the SessionActor on
+// every mutating message.
+func (state *EngineActor) resolveSession(ctx actor.Context, token string) (int, bool) {
+	if entry, ok := state.sessions[token]; ok {
+		if time.Now().After(entry.expiresAt) {
+			delete(state.sessions, token)
+		} else {
+			return entry.userID, true
+		}
+	}
+
+	future := ctx.RequestFuture(state.sessionPID, &resolveSession{Token: token}, requestTimeout)
+	res, err := future.Result()
+	if err != nil {
+		return 0, false
+	}
+	resolution := res.(sessionResolution)
+	if !resolution.Valid {
+		return 0, false
+	}
+	state.sessions[token] = sessionEntry{userID: resolution.UserID, expiresAt: time.Now().Add(SessionTTL)}
+	return resolution.UserID, true
+}
+
+// handleLogin verifies username/password against the stored bcrypt hash and,
+// on success, mints a session token that stands in for UserID on every
+// mutating message from here on.
+func (state *EngineActor) handleLogin(ctx actor.Context, msg *Login) {
+	userID, exists := state.userIDByUsername[msg.Username]
+	if !exists {
+		msg.Reply <- LoginResult{Message: fmt.Sprintf("\033[1;31mUnknown user '%s'.\033[0m", msg.Username)}
+		return
+	}
+
+	future := ctx.RequestFuture(state.userPIDs[userID], &queryUserState{}, requestTimeout)
+	res, err := future.Result()
+	if err != nil {
+		msg.Reply <- LoginResult{Message: fmt.Sprintf("\033[1;31mLogin failed: %v\033[0m", err)}
+		return
+	}
+	user := res.(*User)
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(msg.Password)) != nil {
+		msg.Reply <- LoginResult{Message: "\033[1;31mInvalid username or password.\033[0m"}
+		return
+	}
+
+	tokenFuture := ctx.RequestFuture(state.sessionPID, &createSession{UserID: userID}, requestTimeout)
+	tokenRes, err := tokenFuture.Result()
+	if err != nil {
+		msg.Reply <- LoginResult{Message: fmt.Sprintf("\033[1;31mLogin failed: %v\033[0m", err)}
+		return
+	}
+	token := tokenRes.(string)
+	state.sessions[token] = sessionEntry{userID: userID, expiresAt: time.Now().Add(SessionTTL)}
+	msg.Reply <- LoginResult{
+		Token:   token,
+		UserID:  userID,
+		Message: fmt.Sprintf("\033[1;32mWelcome back, %s.\033[0m", msg.Username),
+	}
+}
+
+func (state *EngineActor) routeMembership(ctx actor.Context, subreddit string, userID int, join bool, reply chan string) {
+	subredditPID, exists := state.subredditPIDs[subreddit]
+	if !exists {
+		reply <- fmt.Sprintf("\033[1;31mSubreddit '%s' does not exist.\033[0m", subreddit)
+		return
+	}
+	ctx.Send(subredditPID, &membershipCmd{UserID: userID, Username: state.getUsername(userID), Subreddit: subreddit, Join: join, Reply: reply})
+}
+
+// routeVote forwards a vote to the SubredditActor that owns postID and, if
+// it changed the post's score, nudges the author's karma by the same
+// amount.
+func (state *EngineActor) routeVote(ctx actor.Context, postID, userID int, up bool, reply chan string) {
+	subredditPID, ok := state.subredditForPost(ctx, postID)
+	if !ok {
+		reply <- fmt.Sprintf("\033[1;31mPost ID '%d' not found.\033[0m", postID)
+		return
+	}
+	future := ctx.RequestFuture(subredditPID, &voteCmd{PostID: postID, UserID: userID, Up: up}, requestTimeout)
+	res, err := future.Result()
+	if err != nil {
+		reply <- fmt.Sprintf("\033[1;31mFailed to record vote: %v\033[0m", err)
+		return
+	}
+	result := res.(voteResult)
+	if !result.Found {
+		reply <- fmt.Sprintf("\033[1;31mPost ID '%d' not found.\033[0m", postID)
+		return
+	}
+	if result.Applied && result.KarmaDelta != 0 {
+		if authorPID, exists := state.userPIDs[result.AuthorID]; exists {
+			ctx.Send(authorPID, &karmaDeltaCmd{Delta: result.KarmaDelta})
+		}
+	}
+	verb := "disliked"
+	if up {
+		verb = "liked"
+	}
+	reply <- fmt.Sprintf("\033[1;32mUser '%s' %s post %d.\033[0m", state.getUsername(userID), verb, postID)
+}
+
+func (state *EngineActor) routeDelivery(ctx actor.Context, receiverID int, label, fromUsername, content string, reply chan string) {
+	receiverPID, exists := state.userPIDs[receiverID]
+	if !exists {
+		reply <- fmt.Sprintf("\033[1;31mUser with ID %d does not exist.\033[0m", receiverID)
+		return
+	}
+	ctx.Send(receiverPID, &deliverMessage{Label: label, FromUsername: fromUsername, Content: content, Reply: reply})
+}
+
+// assignPostID asks the PostIndexActor for the next global post ID and
+// records it against subreddit in one round trip.
+func (state *EngineActor) assignPostID(ctx actor.Context, subreddit string) (int, error) {
+	future := ctx.RequestFuture(state.postIndexPID, &indexAssignPost{Subreddit: subreddit}, requestTimeout)
+	res, err := future.Result()
+	if err != nil {
+		return 0, err
+	}
+	return res.(int), nil
+}
+
+// subredditForPost resolves which SubredditActor owns postID via the
+// PostIndexActor, an O(1) lookup instead of scanning every subreddit.
+func (state *EngineActor) subredditForPost(ctx actor.Context, postID int) (*actor.PID, bool) {
+	future := ctx.RequestFuture(state.postIndexPID, &indexLookupPost{PostID: postID}, requestTimeout)
+	res, err := future.Result()
+	if err != nil {
+		return nil, false
+	}
+	name, ok := res.(string)
+	if !ok || name == "" {
+		return nil, false
+	}
+	pid, exists := state.subredditPIDs[name]
+	return pid, exists
+}
+
+// bootstrap spawns the PostIndexActor plus one SubredditActor/UserActor per
+// entry in state.initial, reconstructing the routing tables and the post
+// index from whatever Store.Replay produced.
+func (state *EngineActor) bootstrap(ctx actor.Context) {
+	state.postIndexPID = ctx.Spawn(actor.PropsFromProducer(func() actor.Actor {
+		return newPostIndexActor(state.initial.NextPostID)
+	}))
+	state.sessionPID = ctx.Spawn(actor.PropsFromProducer(func() actor.Actor {
+		return newSessionActor()
+	}))
+
+	state.nextUserID = state.initial.NextUserID
+	for id, user := range state.initial.Users {
+		state.spawnUser(ctx, user)
+		if id > state.nextUserID {
+			state.nextUserID = id
+		}
+	}
+	for name, subreddit := range state.initial.Subreddits {
+		state.spawnSubreddit(ctx, subreddit)
+		for _, post := range subreddit.Posts {
+			ctx.Send(state.postIndexPID, &indexRestorePost{PostID: post.ID, Subreddit: name})
+		}
+	}
+}
+
+func (state *EngineActor) spawnUser(ctx actor.Context, user *User) {
+	pid := ctx.Spawn(actor.PropsFromProducer(func() actor.Actor { return newUserActor(user, state.store) }))
+	state.userPIDs[user.ID] = pid
+	state.usernames[user.ID] = user.Username
+	state.userIDByUsername[user.Username] = user.ID
+}
+
+func (state *EngineActor) spawnSubreddit(ctx actor.Context, subreddit *Subreddit) {
+	pid := ctx.Spawn(actor.PropsFromProducer(func() actor.Actor { return newSubredditActor(subreddit, state.store) }))
+	state.subredditPIDs[subreddit.Name] = pid
+}
+
+// SnapshotRequest asks EngineActor to gather full state from every child
+// actor and persist it via the Store.
+type SnapshotRequest struct {
+	Done chan error
+}
+
+func (state *EngineActor) handleSnapshotRequest(ctx actor.Context, msg *SnapshotRequest) {
+	if state.store == nil {
+		msg.Done <- nil
+		return
+	}
+	snapshot := State{
+		NextUserID: state.nextUserID,
+		Users:      make(map[int]*User, len(state.userPIDs)),
+		Subreddits: make(map[string]*Subreddit, len(state.subredditPIDs)),
+	}
+
+	for id, pid := range state.userPIDs {
+		future := ctx.RequestFuture(pid, &queryUserState{}, requestTimeout)
+		res, err := future.Result()
+		if err != nil {
+			msg.Done <- fmt.Errorf("query user %d: %w", id, err)
+			return
+		}
+		snapshot.Users[id] = res.(*User)
+	}
+	for name, pid := range state.subredditPIDs {
+		future := ctx.RequestFuture(pid, &querySubredditState{}, requestTimeout)
+		res, err := future.Result()
+		if err != nil {
+			msg.Done <- fmt.Errorf("query subreddit %q: %w", name, err)
+			return
+		}
+		snapshot.Subreddits[name] = res.(*Subreddit)
+	}
+
+	future := ctx.RequestFuture(state.postIndexPID, &indexNextID{}, requestTimeout)
+	res, err := future.Result()
+	if err != nil {
+		msg.Done <- fmt.Errorf("query post index: %w", err)
+		return
+	}
+	snapshot.NextPostID = res.(int)
+
+	msg.Done <- state.store.Snapshot(snapshot)
+}
+
+// StartSnapshotter runs a background goroutine that periodically asks
+// EngineActor to snapshot itself, and returns a func to stop it (which
+// also forces one final snapshot).
+func StartSnapshotter(root *actor.RootContext, enginePID *actor.PID) func() {
+	ticker := time.NewTicker(SnapshotInterval)
+	done := make(chan struct{})
+
+	snapshotNow := func() {
+		result := make(chan error, 1)
+		root.Send(enginePID, &SnapshotRequest{Done: result})
+		if err := <-result; err != nil {
+			log.Printf("store: snapshot: %v", err)
+		}
+	}
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				snapshotNow()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		ticker.Stop()
+		close(done)
+		snapshotNow()
+	}
+}
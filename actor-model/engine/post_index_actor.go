@@ -0,0 +1,58 @@
+package engine
+
+import "github.com/asynkron/protoactor-go/actor"
+
+// indexAssignPost asks the PostIndexActor to mint the next global post ID
+// for subreddit and remember which subreddit owns it.
+type indexAssignPost struct {
+	Subreddit string
+}
+
+// indexLookupPost resolves a post ID to the subreddit name that owns it, so
+// comments/replies can be routed directly to that SubredditActor instead of
+// scanning every subreddit.
+type indexLookupPost struct {
+	PostID int
+}
+
+// indexRestorePost re-populates the index for a post recovered via Replay,
+// without minting a new ID.
+type indexRestorePost struct {
+	PostID    int
+	Subreddit string
+}
+
+// indexNextID asks for the current next-post-ID counter, used when
+// assembling a snapshot.
+type indexNextID struct{}
+
+// postIndexActor is the single owner of the postID -> subreddit name map.
+type postIndexActor struct {
+	bySubreddit map[int]string
+	nextID      int
+}
+
+func newPostIndexActor(nextID int) *postIndexActor {
+	return &postIndexActor{bySubreddit: make(map[int]string), nextID: nextID}
+}
+
+func (a *postIndexActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *indexAssignPost:
+		a.nextID++
+		a.bySubreddit[a.nextID] = msg.Subreddit
+		ctx.Respond(a.nextID)
+
+	case *indexLookupPost:
+		ctx.Respond(a.bySubreddit[msg.PostID])
+
+	case *indexRestorePost:
+		a.bySubreddit[msg.PostID] = msg.Subreddit
+		if msg.PostID > a.nextID {
+			a.nextID = msg.PostID
+		}
+
+	case *indexNextID:
+		ctx.Respond(a.nextID)
+	}
+}
@@ -0,0 +1,74 @@
+package engine
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// redditEpoch is the reference point hot scores are measured from, matching
+// Reddit's original ranking algorithm (2005-12-08T07:46:43Z) so scores stay
+// comparable across posts created on different days.
+var redditEpoch = time.Date(2005, 12, 8, 7, 46, 43, 0, time.UTC)
+
+// RankMode selects how RankFeed orders a subreddit's posts.
+type RankMode int
+
+const (
+	RankHot RankMode = iota
+	RankTop
+	RankNew
+)
+
+func (m RankMode) String() string {
+	switch m {
+	case RankTop:
+		return "top"
+	case RankNew:
+		return "new"
+	default:
+		return "hot"
+	}
+}
+
+// hotScore is Reddit's hot-ranking formula: log10(max(|s|,1)) +
+// sign(s)*Δt/45000, where s is the post's signed score and Δt is seconds
+// since redditEpoch. A bigger score lead decays logarithmically while age
+// still breaks ties, so old heavily-voted posts don't bury fresh ones
+// forever.
+func hotScore(post *Post) float64 {
+	s := float64(post.Upvotes - post.Downvotes)
+	order := math.Log10(math.Max(math.Abs(s), 1))
+
+	sign := 0.0
+	switch {
+	case s > 0:
+		sign = 1
+	case s < 0:
+		sign = -1
+	}
+
+	seconds := post.CreatedAt.Sub(redditEpoch).Seconds()
+	return order + sign*seconds/45000
+}
+
+// rankPosts returns a freshly sorted copy of posts; the input slice is left
+// untouched since it may belong to a live SubredditActor.
+func rankPosts(posts []*Post, mode RankMode) []*Post {
+	ranked := append([]*Post(nil), posts...)
+	switch mode {
+	case RankTop:
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return (ranked[i].Upvotes - ranked[i].Downvotes) > (ranked[j].Upvotes - ranked[j].Downvotes)
+		})
+	case RankNew:
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return ranked[i].CreatedAt.After(ranked[j].CreatedAt)
+		})
+	default:
+		sort.SliceStable(ranked, func(i, j int) bool {
+			return hotScore(ranked[i]) > hotScore(ranked[j])
+		})
+	}
+	return ranked
+}
@@ -0,0 +1,79 @@
+package engine
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// SessionTTL is how long a session token remains valid after Login.
+const SessionTTL = 24 * time.Hour
+
+// createSession asks the SessionActor to mint a token for UserID.
+type createSession struct {
+	UserID int
+}
+
+// resolveSession asks whether Token is still valid and, if so, which user it
+// belongs to.
+type resolveSession struct {
+	Token string
+}
+
+type sessionResolution struct {
+	UserID int
+	Valid  bool
+}
+
+// revokeSession invalidates Token immediately, before its TTL expires.
+type revokeSession struct {
+	Token string
+}
+
+type sessionEntry struct {
+	userID    int
+	expiresAt time.Time
+}
+
+// sessionActor owns the token -> userID table. Like every other actor here,
+// it needs no mutex: ProtoActor only ever delivers one message at a time.
+type sessionActor struct {
+	sessions map[string]sessionEntry
+}
+
+func newSessionActor() *sessionActor {
+	return &sessionActor{sessions: make(map[string]sessionEntry)}
+}
+
+func (a *sessionActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *createSession:
+		token := newSessionToken()
+		a.sessions[token] = sessionEntry{userID: msg.UserID, expiresAt: time.Now().Add(SessionTTL)}
+		ctx.Respond(token)
+
+	case *resolveSession:
+		entry, ok := a.sessions[msg.Token]
+		if !ok || time.Now().After(entry.expiresAt) {
+			delete(a.sessions, msg.Token)
+			ctx.Respond(sessionResolution{})
+			return
+		}
+		ctx.Respond(sessionResolution{UserID: entry.userID, Valid: true})
+
+	case *revokeSession:
+		delete(a.sessions, msg.Token)
+	}
+}
+
+// newSessionToken returns a random 256-bit token, hex-encoded so it's safe
+// to pass around as a plain string.
+func newSessionToken() string {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf)
+}
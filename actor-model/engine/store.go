@@ -0,0 +1,269 @@
+package engine
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	bucketSnapshot = []byte("snapshot")
+	bucketLog      = []byte("log")
+)
+
+// logEvent is a single incremental change appended to the log bucket between
+// snapshots. Replay re-applies these on top of the last snapshot.
+type logEvent struct {
+	Type      string `json:"type"`
+	User      *User  `json:"user,omitempty"`
+	Post      *Post  `json:"post,omitempty"`
+	PostID    int    `json:"post_id,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+	CommentID int    `json:"comment_id,omitempty"`
+	UserID    int    `json:"user_id,omitempty"`
+	Message   string `json:"message,omitempty"`
+	Up        *bool  `json:"up,omitempty"`
+	Subreddit string `json:"subreddit,omitempty"`
+	Join      *bool  `json:"join,omitempty"`
+}
+
+// Store persists engine state so the platform survives restarts.
+type Store interface {
+	SaveUser(user *User) error
+	AppendPost(post *Post) error
+	AppendComment(postID int, comment string) error
+	AppendReply(postID, commentID int, reply string) error
+	AppendInboxMessage(userID int, message string) error
+	AppendVote(postID, userID int, up bool) error
+	AppendSubreddit(name string) error
+	AppendMembership(subreddit string, userID int, join bool) error
+	Snapshot(state State) error
+	Replay() (State, error)
+	Close() error
+}
+
+// BoltStore is a Store backed by a local BoltDB file.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// NewBoltStore opens (creating if necessary) a BoltDB file at path.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("open store %q: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(bucketSnapshot); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(bucketLog)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &BoltStore{db: db}, nil
+}
+
+func (s *BoltStore) appendEvent(ev logEvent) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketLog)
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(itob(seq), data)
+	})
+}
+
+func (s *BoltStore) SaveUser(user *User) error {
+	return s.appendEvent(logEvent{Type: "user", User: user})
+}
+
+func (s *BoltStore) AppendPost(post *Post) error {
+	return s.appendEvent(logEvent{Type: "post", Post: post})
+}
+
+func (s *BoltStore) AppendComment(postID int, comment string) error {
+	return s.appendEvent(logEvent{Type: "comment", PostID: postID, Comment: comment})
+}
+
+// AppendReply records a reply appended to an existing comment, so Replay can
+// reconstruct it without needing a fresh snapshot.
+func (s *BoltStore) AppendReply(postID, commentID int, reply string) error {
+	return s.appendEvent(logEvent{Type: "reply", PostID: postID, CommentID: commentID, Comment: reply})
+}
+
+func (s *BoltStore) AppendInboxMessage(userID int, message string) error {
+	return s.appendEvent(logEvent{Type: "inbox", UserID: userID, Message: message})
+}
+
+func (s *BoltStore) AppendVote(postID, userID int, up bool) error {
+	return s.appendEvent(logEvent{Type: "vote", PostID: postID, UserID: userID, Up: &up})
+}
+
+func (s *BoltStore) AppendSubreddit(name string) error {
+	return s.appendEvent(logEvent{Type: "subreddit", Subreddit: name})
+}
+
+func (s *BoltStore) AppendMembership(subreddit string, userID int, join bool) error {
+	return s.appendEvent(logEvent{Type: "membership", Subreddit: subreddit, UserID: userID, Join: &join})
+}
+
+// Snapshot captures the full engine state and truncates the log, since
+// everything before this point is now represented in the snapshot.
+func (s *BoltStore) Snapshot(state State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(bucketSnapshot).Put([]byte("state"), data); err != nil {
+			return err
+		}
+		log := tx.Bucket(bucketLog)
+		// Collect the keys first: deleting while a cursor walks the bucket
+		// can make the cursor skip keys.
+		var keys [][]byte
+		c := log.Cursor()
+		for k, _ := c.First(); k != nil; k, _ = c.Next() {
+			keys = append(keys, append([]byte(nil), k...))
+		}
+		for _, k := range keys {
+			if err := log.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Replay reconstructs state from the last snapshot plus any log entries
+// appended since, in order.
+func (s *BoltStore) Replay() (State, error) {
+	state := State{
+		Users:      make(map[int]*User),
+		Subreddits: make(map[string]*Subreddit),
+	}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if data := tx.Bucket(bucketSnapshot).Get([]byte("state")); data != nil {
+			var blob State
+			if err := json.Unmarshal(data, &blob); err != nil {
+				return err
+			}
+			state.NextUserID = blob.NextUserID
+			state.NextPostID = blob.NextPostID
+			if blob.Users != nil {
+				state.Users = blob.Users
+			}
+			if blob.Subreddits != nil {
+				state.Subreddits = blob.Subreddits
+			}
+		}
+
+		c := tx.Bucket(bucketLog).Cursor()
+		for _, data := c.First(); data != nil; _, data = c.Next() {
+			var ev logEvent
+			if err := json.Unmarshal(data, &ev); err != nil {
+				return err
+			}
+			applyLogEvent(&state, ev)
+		}
+		return nil
+	})
+	return state, err
+}
+
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+func applyLogEvent(state *State, ev logEvent) {
+	switch ev.Type {
+	case "user":
+		state.Users[ev.User.ID] = ev.User
+		if ev.User.ID > state.NextUserID {
+			state.NextUserID = ev.User.ID
+		}
+	case "post":
+		if subreddit, exists := state.Subreddits[ev.Post.Subreddit]; exists {
+			subreddit.Posts = append(subreddit.Posts, ev.Post)
+			if ev.Post.ID > state.NextPostID {
+				state.NextPostID = ev.Post.ID
+			}
+		}
+	case "comment":
+		for _, subreddit := range state.Subreddits {
+			for _, post := range subreddit.Posts {
+				if post.ID == ev.PostID {
+					post.Comments = append(post.Comments, ev.Comment)
+				}
+			}
+		}
+	case "reply":
+		for _, subreddit := range state.Subreddits {
+			for _, post := range subreddit.Posts {
+				if post.ID != ev.PostID {
+					continue
+				}
+				if ev.CommentID < 1 || ev.CommentID > len(post.Comments) {
+					return
+				}
+				post.Comments[ev.CommentID-1] += ev.Comment
+				return
+			}
+		}
+	case "inbox":
+		if user, exists := state.Users[ev.UserID]; exists {
+			user.Inbox = append(user.Inbox, ev.Message)
+		}
+	case "subreddit":
+		if _, exists := state.Subreddits[ev.Subreddit]; !exists {
+			state.Subreddits[ev.Subreddit] = &Subreddit{Name: ev.Subreddit, Users: map[int]bool{}}
+		}
+	case "membership":
+		if ev.Join == nil {
+			return
+		}
+		subreddit, exists := state.Subreddits[ev.Subreddit]
+		if !exists {
+			return
+		}
+		if *ev.Join {
+			subreddit.Users[ev.UserID] = true
+		} else {
+			delete(subreddit.Users, ev.UserID)
+		}
+	case "vote":
+		if ev.Up == nil {
+			return
+		}
+		for _, subreddit := range state.Subreddits {
+			for _, post := range subreddit.Posts {
+				if post.ID != ev.PostID {
+					continue
+				}
+				delta := applyVote(post, ev.UserID, *ev.Up)
+				if author, exists := state.Users[post.UserID]; exists {
+					author.Karma += delta
+				}
+				return
+			}
+		}
+	}
+}
+
+func itob(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
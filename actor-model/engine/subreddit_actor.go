@@ -0,0 +1,216 @@
+package engine
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// membershipCmd joins or removes UserID from the subreddit.
+type membershipCmd struct {
+	UserID    int
+	Username  string
+	Subreddit string
+	Join      bool
+	Reply     chan string
+}
+
+// createPostCmd creates a post with a PostID already assigned by the
+// PostIndexActor.
+type createPostCmd struct {
+	PostID   int
+	UserID   int
+	Username string
+	Content  string
+	Reply    chan CreatePostResult
+}
+
+// commentCmd appends a top-level comment to an existing post owned by this
+// subreddit.
+type commentCmd struct {
+	PostID   int
+	Username string
+	Content  string
+	Reply    chan string
+}
+
+// replyCmd appends a reply to an existing comment.
+type replyCmd struct {
+	PostID    int
+	CommentID int
+	Username  string
+	Content   string
+	Reply     chan string
+}
+
+// voteCmd records UserID's vote on PostID, replacing any earlier vote of
+// theirs on the same post.
+type voteCmd struct {
+	PostID int
+	UserID int
+	Up     bool
+}
+
+// voteResult reports whether PostID existed and, if the vote actually
+// changed the post's score, how much so the caller can keep the author's
+// karma in sync.
+type voteResult struct {
+	Found      bool
+	Applied    bool
+	AuthorID   int
+	KarmaDelta int
+}
+
+// querySubredditState asks for a copy of the subreddit's current state, used
+// when assembling a snapshot.
+type querySubredditState struct{}
+
+// subredditActor owns every Post and membership for a single subreddit. No
+// mutex is needed: ProtoActor only ever delivers one message at a time to a
+// given actor instance.
+type subredditActor struct {
+	state *Subreddit
+	store Store
+}
+
+func newSubredditActor(state *Subreddit, store Store) *subredditActor {
+	return &subredditActor{state: state, store: store}
+}
+
+func (a *subredditActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *membershipCmd:
+		if msg.Join {
+			a.state.Users[msg.UserID] = true
+		} else {
+			delete(a.state.Users, msg.UserID)
+		}
+		if a.store != nil {
+			if err := a.store.AppendMembership(msg.Subreddit, msg.UserID, msg.Join); err != nil {
+				log.Printf("store: append membership for subreddit %q: %v", msg.Subreddit, err)
+			}
+		}
+		if msg.Join {
+			msg.Reply <- fmt.Sprintf("\033[1;32mUser '%s' joined subreddit '%s'.\033[0m", msg.Username, msg.Subreddit)
+		} else {
+			msg.Reply <- fmt.Sprintf("\033[1;32mUser '%s' left subreddit '%s'.\033[0m", msg.Username, msg.Subreddit)
+		}
+
+	case *createPostCmd:
+		post := &Post{ID: msg.PostID, UserID: msg.UserID, Subreddit: a.state.Name, Content: msg.Content, CreatedAt: time.Now()}
+		a.state.Posts = append(a.state.Posts, post)
+		if a.store != nil {
+			if err := a.store.AppendPost(post); err != nil {
+				log.Printf("store: append post %d: %v", post.ID, err)
+			}
+		}
+		msg.Reply <- CreatePostResult{
+			ID:      post.ID,
+			Message: fmt.Sprintf("\033[1;32mUser '%s' posted in '%s': %s\033[0m", msg.Username, a.state.Name, msg.Content),
+		}
+
+	case *commentCmd:
+		for _, post := range a.state.Posts {
+			if post.ID == msg.PostID {
+				comment := fmt.Sprintf("User '%s': %s", msg.Username, msg.Content)
+				post.Comments = append(post.Comments, comment)
+				if a.store != nil {
+					if err := a.store.AppendComment(msg.PostID, comment); err != nil {
+						log.Printf("store: append comment on post %d: %v", msg.PostID, err)
+					}
+				}
+				msg.Reply <- fmt.Sprintf("\033[1;32mUser '%s' commented on post %d: %s\033[0m", msg.Username, msg.PostID, msg.Content)
+				return
+			}
+		}
+		msg.Reply <- fmt.Sprintf("\033[1;31mPost ID '%d' not found.\033[0m", msg.PostID)
+
+	case *replyCmd:
+		for _, post := range a.state.Posts {
+			if post.ID != msg.PostID {
+				continue
+			}
+			if msg.CommentID < 1 || msg.CommentID > len(post.Comments) {
+				break
+			}
+			commentIndex := msg.CommentID - 1
+			replyText := fmt.Sprintf("\nReply by '%s': %s", msg.Username, msg.Content)
+			post.Comments[commentIndex] += replyText
+			if a.store != nil {
+				if err := a.store.AppendReply(msg.PostID, msg.CommentID, replyText); err != nil {
+					log.Printf("store: append reply to comment %d on post %d: %v", msg.CommentID, msg.PostID, err)
+				}
+			}
+			msg.Reply <- fmt.Sprintf("\033[1;32mUser '%s' replied to comment %d on post %d: %s\033[0m", msg.Username, msg.CommentID, msg.PostID, msg.Content)
+			return
+		}
+		msg.Reply <- fmt.Sprintf("\033[1;31mComment or Post not found.\033[0m")
+
+	case *voteCmd:
+		for _, post := range a.state.Posts {
+			if post.ID != msg.PostID {
+				continue
+			}
+			delta := applyVote(post, msg.UserID, msg.Up)
+			if a.store != nil && delta != 0 {
+				if err := a.store.AppendVote(msg.PostID, msg.UserID, msg.Up); err != nil {
+					log.Printf("store: append vote on post %d: %v", msg.PostID, err)
+				}
+			}
+			ctx.Respond(voteResult{Found: true, Applied: delta != 0, AuthorID: post.UserID, KarmaDelta: delta})
+			return
+		}
+		ctx.Respond(voteResult{Found: false})
+
+	case *querySubredditState:
+		cp := &Subreddit{Name: a.state.Name, Users: make(map[int]bool, len(a.state.Users)), Posts: make([]*Post, 0, len(a.state.Posts))}
+		for id := range a.state.Users {
+			cp.Users[id] = true
+		}
+		for _, post := range a.state.Posts {
+			postCopy := *post
+			postCopy.Comments = append([]string(nil), post.Comments...)
+			if post.Voters != nil {
+				postCopy.Voters = make(map[int]int8, len(post.Voters))
+				for voter, dir := range post.Voters {
+					postCopy.Voters[voter] = dir
+				}
+			}
+			cp.Posts = append(cp.Posts, &postCopy)
+		}
+		ctx.Respond(cp)
+	}
+}
+
+// applyVote replaces userID's existing vote on post (if any) with the new
+// one and returns the resulting change in score (Upvotes - Downvotes), so
+// callers can keep derived aggregates like the author's karma in sync.
+// Voting the same way twice is a no-op.
+func applyVote(post *Post, userID int, up bool) int {
+	if post.Voters == nil {
+		post.Voters = make(map[int]int8)
+	}
+	newDir := int8(1)
+	if !up {
+		newDir = -1
+	}
+	oldDir := post.Voters[userID]
+	if oldDir == newDir {
+		return 0
+	}
+	switch oldDir {
+	case 1:
+		post.Upvotes--
+	case -1:
+		post.Downvotes--
+	}
+	if newDir == 1 {
+		post.Upvotes++
+	} else {
+		post.Downvotes++
+	}
+	post.Voters[userID] = newDir
+	return int(newDir) - int(oldDir)
+}
@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// deliverMessage appends an inbox entry of the form "<Label> from
+// '<FromUsername>': <Content>".
+type deliverMessage struct {
+	Label        string
+	FromUsername string
+	Content      string
+	Reply        chan string
+}
+
+// viewInboxCmd asks for the user's inbox rendered as a single string.
+type viewInboxCmd struct {
+	Reply chan string
+}
+
+// queryUserState asks for a copy of the user's current state, used when
+// assembling a snapshot.
+type queryUserState struct{}
+
+// karmaDeltaCmd adjusts the user's karma by Delta, sent whenever one of
+// their posts gains or loses a vote.
+type karmaDeltaCmd struct {
+	Delta int
+}
+
+// userActor owns a single User's inbox. No mutex is needed: ProtoActor only
+// ever delivers one message at a time to a given actor instance.
+type userActor struct {
+	state *User
+	store Store
+}
+
+func newUserActor(state *User, store Store) *userActor {
+	return &userActor{state: state, store: store}
+}
+
+func (a *userActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *deliverMessage:
+		entry := fmt.Sprintf("%s from '%s': %s", msg.Label, msg.FromUsername, msg.Content)
+		a.state.Inbox = append(a.state.Inbox, entry)
+		if a.store != nil {
+			if err := a.store.AppendInboxMessage(a.state.ID, entry); err != nil {
+				log.Printf("store: append inbox message for user %d: %v", a.state.ID, err)
+			}
+		}
+		msg.Reply <- fmt.Sprintf("\033[1;32m%s sent to User '%s'.\033[0m", msg.Label, a.state.Username)
+
+	case *viewInboxCmd:
+		if len(a.state.Inbox) == 0 {
+			msg.Reply <- "\033[1;32mInbox is empty.\033[0m"
+			return
+		}
+		msg.Reply <- fmt.Sprintf("\033[1;32mInbox:\n%s\033[0m", strings.Join(a.state.Inbox, "\n"))
+
+	case *karmaDeltaCmd:
+		a.state.Karma += msg.Delta
+
+	case *queryUserState:
+		cp := &User{ID: a.state.ID, Username: a.state.Username, PasswordHash: a.state.PasswordHash, Karma: a.state.Karma, Inbox: append([]string(nil), a.state.Inbox...)}
+		ctx.Respond(cp)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/asynkron/protoactor-go/actor"
+
+	"github.com/hemanth-19/secure-reddit-clone-go/actor-model/bridge/matrix"
+	"github.com/hemanth-19/secure-reddit-clone-go/actor-model/bridge/reddit"
+	"github.com/hemanth-19/secure-reddit-clone-go/actor-model/cmd/cli"
+	"github.com/hemanth-19/secure-reddit-clone-go/actor-model/engine"
+	"github.com/hemanth-19/secure-reddit-clone-go/actor-model/tui"
+)
+
+// supervisorActor logs errors forwarded by bridge actors.
+type supervisorActor struct{}
+
+func (supervisorActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *reddit.BridgeError:
+		log.Printf("bridge error (%s): %v", msg.Source, msg.Err)
+	}
+}
+
+// Main Function
+func main() {
+	storePath := flag.String("store", "reddit.db", "path to the BoltDB file used for persistence")
+	enableRedditBridge := flag.Bool("reddit-bridge", false, "mirror a real Reddit account's activity into the engine")
+	redditAppID := flag.String("reddit-app-id", os.Getenv("REDDIT_APP_ID"), "Reddit app ID for the bridge bot")
+	redditSecret := flag.String("reddit-secret", os.Getenv("REDDIT_SECRET"), "Reddit app secret for the bridge bot")
+	redditUsername := flag.String("reddit-username", os.Getenv("REDDIT_USERNAME"), "Reddit account username for the bridge bot")
+	redditPassword := flag.String("reddit-password", os.Getenv("REDDIT_PASSWORD"), "Reddit account password for the bridge bot")
+	enableMatrixBridge := flag.Bool("matrix-bridge", false, "expose the engine as a Matrix appservice")
+	matrixRegistration := flag.String("matrix-registration", "registration.yaml", "path to the Matrix appservice registration file")
+	matrixHomeserverDomain := flag.String("matrix-homeserver-domain", os.Getenv("MATRIX_HOMESERVER_DOMAIN"), "Matrix homeserver domain used in puppeted MXIDs and room aliases")
+	matrixHomeserverURL := flag.String("matrix-homeserver-url", os.Getenv("MATRIX_HOMESERVER_URL"), "Matrix homeserver URL the appservice connects to")
+	useLegacyCLI := flag.Bool("cli", false, "use the legacy numeric menu instead of the TUI")
+	flag.Parse()
+
+	store, err := engine.NewBoltStore(*storePath)
+	if err != nil {
+		log.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	initialState, err := store.Replay()
+	if err != nil {
+		log.Fatalf("replay store: %v", err)
+	}
+	engineActor := engine.NewEngineActor(store, initialState)
+
+	engineProps := actor.PropsFromProducer(func() actor.Actor { return engineActor })
+	actorSystem := actor.NewActorSystem()
+	enginePID := actorSystem.Root.Spawn(engineProps)
+
+	stopSnapshotter := engine.StartSnapshotter(actorSystem.Root, enginePID)
+	defer stopSnapshotter()
+
+	if *enableRedditBridge {
+		supervisorPID := actorSystem.Root.Spawn(actor.PropsFromProducer(func() actor.Actor { return &supervisorActor{} }))
+		bridgeCfg := reddit.BotConfig{
+			AppID:    *redditAppID,
+			Secret:   *redditSecret,
+			Username: *redditUsername,
+			Password: *redditPassword,
+		}
+		bridgeProps := actor.PropsFromProducer(func() actor.Actor {
+			return reddit.NewRedditBridgeActor(bridgeCfg, enginePID, supervisorPID)
+		})
+		bridgePID := actorSystem.Root.Spawn(bridgeProps)
+		defer actorSystem.Root.Stop(bridgePID)
+	}
+
+	if *enableMatrixBridge {
+		matrixCfg := matrix.Config{
+			RegistrationPath: *matrixRegistration,
+			HomeserverDomain: *matrixHomeserverDomain,
+			HomeserverURL:    *matrixHomeserverURL,
+		}
+		matrixProps := actor.PropsFromProducer(func() actor.Actor {
+			return matrix.NewMatrixBridgeActor(matrixCfg, enginePID)
+		})
+		matrixPID := actorSystem.Root.Spawn(matrixProps)
+		defer actorSystem.Root.Stop(matrixPID)
+	}
+
+	if *useLegacyCLI {
+		cli.Run(actorSystem.Root, enginePID)
+		return
+	}
+
+	if _, err := tui.NewProgram(actorSystem.Root, enginePID).Run(); err != nil {
+		log.Fatalf("tui: %v", err)
+	}
+}
@@ -0,0 +1,819 @@
+// Package tui is a Bubble Tea terminal UI for the engine, modeled on
+// neonmodem's forum browser: a left pane of subreddits, a center pane of
+// posts, and a right pane showing the selected post's comment tree. A
+// separate inbox view covers direct messages. Engine replies are delivered
+// over the same Reply channels the CLI uses, wrapped in tea.Cmd so the
+// program loop never blocks waiting on the actor system.
+package tui
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/hemanth-19/secure-reddit-clone-go/actor-model/engine"
+)
+
+// pane identifies which of the three browse panes has keyboard focus.
+type pane int
+
+const (
+	paneSubreddits pane = iota
+	panePosts
+	paneComments
+)
+
+// stage identifies the top-level screen being shown.
+type stage int
+
+const (
+	stageLogin stage = iota
+	stageBrowse
+	stageInbox
+)
+
+// composeKind identifies what a pending text input submission is for.
+type composeKind int
+
+const (
+	composeNone composeKind = iota
+	composeSubreddit
+	composePost
+	composeComment
+	composeReply
+	composeMessage
+)
+
+// account is a cached login: the session token lets switching back to a
+// previously used account skip Login entirely, as long as the token hasn't
+// expired or been revoked.
+type account struct {
+	username string
+	userID   int
+	token    string
+}
+
+// model is the Bubble Tea root model.
+type model struct {
+	root      *actor.RootContext
+	enginePID *actor.PID
+
+	stage stage
+	width int
+
+	// Login screen.
+	usernameInput textinput.Model
+	passwordInput textinput.Model
+	accounts      []account
+
+	// Current session.
+	username     string
+	userID       int
+	sessionToken string
+
+	// Browse screen.
+	activePane      pane
+	subreddits      []string
+	subredditCursor int
+	posts           []*engine.Post
+	postCursor      int
+	commentCursor   int
+	rankMode        engine.RankMode
+
+	// Inbox screen.
+	inbox []string
+
+	// Compose overlay.
+	composing    composeKind
+	composeInput textinput.Model
+
+	status string
+	err    string
+}
+
+// NewProgram builds the Bubble Tea program driving enginePID over root.
+func NewProgram(root *actor.RootContext, enginePID *actor.PID) *tea.Program {
+	return tea.NewProgram(newModel(root, enginePID))
+}
+
+func newModel(root *actor.RootContext, enginePID *actor.PID) *model {
+	usernameInput := textinput.New()
+	usernameInput.Placeholder = "username"
+	usernameInput.Focus()
+
+	passwordInput := textinput.New()
+	passwordInput.Placeholder = "password"
+	passwordInput.EchoMode = textinput.EchoPassword
+	passwordInput.EchoCharacter = '•'
+
+	composeInput := textinput.New()
+
+	return &model{
+		root:          root,
+		enginePID:     enginePID,
+		stage:         stageLogin,
+		usernameInput: usernameInput,
+		passwordInput: passwordInput,
+		composeInput:  composeInput,
+	}
+}
+
+func (m *model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		return m, nil
+
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+
+	case actionResultMsg:
+		m.status = string(msg)
+		return m, nil
+
+	case loginResultMsg:
+		if msg.result.Token == "" {
+			m.err = msg.result.Message
+			return m, nil
+		}
+		m.loginAs(msg.username, msg.result.UserID, msg.result.Token)
+		m.status = msg.result.Message
+		return m, m.listSubredditsCmd()
+
+	case subredditsMsg:
+		m.subreddits = []string(msg)
+		if m.subredditCursor >= len(m.subreddits) {
+			m.subredditCursor = 0
+		}
+		return m, nil
+
+	case subredditLoadedMsg:
+		if msg.subreddit == nil {
+			m.err = "subreddit not found"
+			return m, nil
+		}
+		m.posts = msg.subreddit.Posts
+		m.postCursor = 0
+		m.commentCursor = 0
+		m.activePane = panePosts
+		return m, nil
+
+	case inboxMsg:
+		m.inbox = []string(msg)
+		return m, nil
+
+	case rankedPostsMsg:
+		m.posts = []*engine.Post(msg)
+		m.postCursor = 0
+		m.commentCursor = 0
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	switch m.stage {
+	case stageLogin:
+		if m.usernameInput.Focused() {
+			m.usernameInput, cmd = m.usernameInput.Update(msg)
+		} else {
+			m.passwordInput, cmd = m.passwordInput.Update(msg)
+		}
+	default:
+		if m.composing != composeNone {
+			m.composeInput, cmd = m.composeInput.Update(msg)
+		}
+	}
+	return m, cmd
+}
+
+func (m *model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.Type == tea.KeyCtrlC {
+		return m, tea.Quit
+	}
+
+	switch m.stage {
+	case stageLogin:
+		return m.handleLoginKey(msg)
+	case stageInbox:
+		return m.handleInboxKey(msg)
+	default:
+		if m.composing != composeNone {
+			return m.handleComposeKey(msg)
+		}
+		return m.handleBrowseKey(msg)
+	}
+}
+
+func (m *model) handleLoginKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Before anything has been typed, a bare digit jumps straight to that
+	// cached account's session instead of typing credentials again.
+	if m.usernameInput.Value() == "" && m.passwordInput.Value() == "" {
+		if n, err := strconv.Atoi(msg.String()); err == nil && n >= 1 && n <= len(m.accounts) {
+			account := m.accounts[n-1]
+			m.loginAs(account.username, account.userID, account.token)
+			return m, m.listSubredditsCmd()
+		}
+	}
+
+	switch msg.Type {
+	case tea.KeyTab:
+		if m.usernameInput.Focused() {
+			m.usernameInput.Blur()
+			m.passwordInput.Focus()
+		} else {
+			m.passwordInput.Blur()
+			m.usernameInput.Focus()
+		}
+		return m, nil
+
+	case tea.KeyEnter:
+		username, password := m.usernameInput.Value(), m.passwordInput.Value()
+		if username == "" || password == "" {
+			m.err = "username and password are required"
+			return m, nil
+		}
+		return m, m.loginCmd(username, password)
+
+	case tea.KeyCtrlN:
+		username, password := m.usernameInput.Value(), m.passwordInput.Value()
+		if username == "" || password == "" {
+			m.err = "username and password are required"
+			return m, nil
+		}
+		return m, m.registerCmd(username, password)
+	}
+
+	var cmd tea.Cmd
+	if m.usernameInput.Focused() {
+		m.usernameInput, cmd = m.usernameInput.Update(msg)
+	} else {
+		m.passwordInput, cmd = m.passwordInput.Update(msg)
+	}
+	return m, cmd
+}
+
+// loginAs switches to the browse screen as the given session, remembering it
+// for future account-switching without asking for a password again.
+func (m *model) loginAs(username string, userID int, token string) {
+	m.username = username
+	m.userID = userID
+	m.sessionToken = token
+	found := false
+	for i, a := range m.accounts {
+		if a.username == username {
+			m.accounts[i].token = token
+			m.accounts[i].userID = userID
+			found = true
+			break
+		}
+	}
+	if !found {
+		m.accounts = append(m.accounts, account{username: username, userID: userID, token: token})
+	}
+	m.stage = stageBrowse
+	m.err = ""
+}
+
+func (m *model) handleBrowseKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "tab":
+		m.activePane = (m.activePane + 1) % 3
+		return m, nil
+	case "up", "k":
+		m.moveCursor(-1)
+		return m, nil
+	case "down", "j":
+		m.moveCursor(1)
+		return m, nil
+	case "enter":
+		return m.selectCurrent()
+	case "s":
+		return m.startCompose(composeSubreddit, "new subreddit name")
+	case "n":
+		if len(m.subreddits) == 0 {
+			m.err = "select a subreddit first"
+			return m, nil
+		}
+		return m.startCompose(composePost, "post content")
+	case "c":
+		if len(m.posts) == 0 {
+			m.err = "select a post first"
+			return m, nil
+		}
+		return m.startCompose(composeComment, "comment content")
+	case "r":
+		if len(m.posts) == 0 || m.commentCursor >= len(m.posts[m.postCursor].Comments) {
+			m.err = "select a comment first"
+			return m, nil
+		}
+		return m.startCompose(composeReply, "reply content")
+	case "g":
+		if len(m.posts) == 0 {
+			m.err = "select a post first"
+			return m, nil
+		}
+		return m, m.votePostCmd(true)
+	case "b":
+		if len(m.posts) == 0 {
+			m.err = "select a post first"
+			return m, nil
+		}
+		return m, m.votePostCmd(false)
+	case "J":
+		if len(m.subreddits) == 0 {
+			m.err = "select a subreddit first"
+			return m, nil
+		}
+		return m, m.membershipCmd(true)
+	case "x":
+		if len(m.subreddits) == 0 {
+			m.err = "select a subreddit first"
+			return m, nil
+		}
+		return m, m.membershipCmd(false)
+	case "o":
+		if m.selectedSubreddit() == "" {
+			m.err = "select a subreddit first"
+			return m, nil
+		}
+		m.rankMode = (m.rankMode + 1) % 3
+		return m, m.rankFeedCmd(m.selectedSubreddit(), m.rankMode)
+	case "i":
+		m.stage = stageInbox
+		return m, m.viewInboxCmd()
+	case "m":
+		return m.startCompose(composeMessage, "receiverID content")
+	case "a":
+		m.stage = stageLogin
+		m.usernameInput.SetValue("")
+		m.passwordInput.SetValue("")
+		m.usernameInput.Focus()
+		m.passwordInput.Blur()
+		return m, m.logoutCmd()
+	}
+	return m, nil
+}
+
+func (m *model) moveCursor(delta int) {
+	switch m.activePane {
+	case paneSubreddits:
+		m.subredditCursor = clamp(m.subredditCursor+delta, len(m.subreddits))
+	case panePosts:
+		m.postCursor = clamp(m.postCursor+delta, len(m.posts))
+		m.commentCursor = 0
+	case paneComments:
+		m.commentCursor = clamp(m.commentCursor+delta, m.commentCount())
+	}
+}
+
+func (m *model) commentCount() int {
+	if m.postCursor >= len(m.posts) {
+		return 0
+	}
+	return len(m.posts[m.postCursor].Comments)
+}
+
+func clamp(v, n int) int {
+	if n == 0 {
+		return 0
+	}
+	if v < 0 {
+		return 0
+	}
+	if v >= n {
+		return n - 1
+	}
+	return v
+}
+
+func (m *model) selectCurrent() (tea.Model, tea.Cmd) {
+	switch m.activePane {
+	case paneSubreddits:
+		if m.subredditCursor >= len(m.subreddits) {
+			return m, nil
+		}
+		return m, m.viewSubredditCmd(m.subreddits[m.subredditCursor])
+	case panePosts:
+		m.activePane = paneComments
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m *model) startCompose(kind composeKind, placeholder string) (tea.Model, tea.Cmd) {
+	m.composing = kind
+	m.composeInput.SetValue("")
+	m.composeInput.Placeholder = placeholder
+	m.composeInput.Focus()
+	return m, textinput.Blink
+}
+
+func (m *model) handleComposeKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.composing = composeNone
+		m.composeInput.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		return m.submitCompose()
+	}
+	var cmd tea.Cmd
+	m.composeInput, cmd = m.composeInput.Update(msg)
+	return m, cmd
+}
+
+func (m *model) handleInboxKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "i", "q":
+		m.stage = stageBrowse
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m *model) submitCompose() (tea.Model, tea.Cmd) {
+	value := m.composeInput.Value()
+	kind := m.composing
+	m.composing = composeNone
+	m.composeInput.Blur()
+	if value == "" {
+		return m, nil
+	}
+
+	switch kind {
+	case composeSubreddit:
+		return m, m.createSubredditCmd(value)
+	case composePost:
+		return m, m.createPostCmd(value)
+	case composeComment:
+		return m, m.commentCmd(value)
+	case composeReply:
+		return m, m.replyCmd(value)
+	case composeMessage:
+		return m, m.sendMessageCmd(value)
+	}
+	return m, nil
+}
+
+func (m *model) selectedSubreddit() string {
+	if m.subredditCursor >= len(m.subreddits) {
+		return ""
+	}
+	return m.subreddits[m.subredditCursor]
+}
+
+func (m *model) selectedPost() *engine.Post {
+	if m.postCursor >= len(m.posts) {
+		return nil
+	}
+	return m.posts[m.postCursor]
+}
+
+// engineRequest blocks on reply, used inside tea.Cmd closures which
+// Bubble Tea always runs off the UI goroutine.
+func engineRequest(root *actor.RootContext, enginePID *actor.PID, msg interface{}, reply chan string) string {
+	root.Send(enginePID, msg)
+	return <-reply
+}
+
+func (m *model) loginCmd(username, password string) tea.Cmd {
+	root, enginePID := m.root, m.enginePID
+	return func() tea.Msg {
+		reply := make(chan engine.LoginResult, 1)
+		root.Send(enginePID, &engine.Login{Username: username, Password: password, Reply: reply})
+		return loginResultMsg{username: username, result: <-reply}
+	}
+}
+
+func (m *model) registerCmd(username, password string) tea.Cmd {
+	root, enginePID := m.root, m.enginePID
+	return func() tea.Msg {
+		reply := make(chan string, 1)
+		engineRequest(root, enginePID, &engine.RegisterUser{Username: username, Password: password, Reply: reply}, reply)
+
+		loginReply := make(chan engine.LoginResult, 1)
+		root.Send(enginePID, &engine.Login{Username: username, Password: password, Reply: loginReply})
+		return loginResultMsg{username: username, result: <-loginReply}
+	}
+}
+
+func (m *model) createSubredditCmd(name string) tea.Cmd {
+	root, enginePID, token := m.root, m.enginePID, m.sessionToken
+	create := func() tea.Msg {
+		reply := make(chan string, 1)
+		text := engineRequest(root, enginePID, &engine.CreateSubreddit{SessionToken: token, Subreddit: name, Reply: reply}, reply)
+		return actionResultMsg(text)
+	}
+	return tea.Batch(create, m.listSubredditsCmd())
+}
+
+func (m *model) membershipCmd(join bool) tea.Cmd {
+	root, enginePID, token, subreddit := m.root, m.enginePID, m.sessionToken, m.selectedSubreddit()
+	return func() tea.Msg {
+		reply := make(chan string, 1)
+		var text string
+		if join {
+			text = engineRequest(root, enginePID, &engine.JoinSubreddit{SessionToken: token, Subreddit: subreddit, Reply: reply}, reply)
+		} else {
+			text = engineRequest(root, enginePID, &engine.LeaveSubreddit{SessionToken: token, Subreddit: subreddit, Reply: reply}, reply)
+		}
+		return actionResultMsg(text)
+	}
+}
+
+func (m *model) createPostCmd(content string) tea.Cmd {
+	root, enginePID, token, subreddit := m.root, m.enginePID, m.sessionToken, m.selectedSubreddit()
+	create := func() tea.Msg {
+		reply := make(chan engine.CreatePostResult, 1)
+		root.Send(enginePID, &engine.CreatePost{SessionToken: token, Subreddit: subreddit, Content: content, Reply: reply})
+		return actionResultMsg((<-reply).Message)
+	}
+	return tea.Batch(create, m.viewSubredditCmd(subreddit))
+}
+
+func (m *model) commentCmd(content string) tea.Cmd {
+	root, enginePID, token := m.root, m.enginePID, m.sessionToken
+	post := m.selectedPost()
+	if post == nil {
+		return nil
+	}
+	create := func() tea.Msg {
+		reply := make(chan string, 1)
+		text := engineRequest(root, enginePID, &engine.CommentOnPost{PostID: post.ID, SessionToken: token, Content: content, Reply: reply}, reply)
+		return actionResultMsg(text)
+	}
+	return tea.Batch(create, m.viewSubredditCmd(m.selectedSubreddit()))
+}
+
+func (m *model) replyCmd(content string) tea.Cmd {
+	root, enginePID, token := m.root, m.enginePID, m.sessionToken
+	post := m.selectedPost()
+	if post == nil {
+		return nil
+	}
+	commentID := m.commentCursor + 1
+	create := func() tea.Msg {
+		reply := make(chan string, 1)
+		text := engineRequest(root, enginePID, &engine.ReplyToComment{PostID: post.ID, CommentID: commentID, SessionToken: token, Content: content, Reply: reply}, reply)
+		return actionResultMsg(text)
+	}
+	return tea.Batch(create, m.viewSubredditCmd(m.selectedSubreddit()))
+}
+
+func (m *model) votePostCmd(like bool) tea.Cmd {
+	root, enginePID, token := m.root, m.enginePID, m.sessionToken
+	post := m.selectedPost()
+	if post == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		reply := make(chan string, 1)
+		var text string
+		if like {
+			text = engineRequest(root, enginePID, &engine.LikePost{PostID: post.ID, SessionToken: token, Reply: reply}, reply)
+		} else {
+			text = engineRequest(root, enginePID, &engine.DislikePost{PostID: post.ID, SessionToken: token, Reply: reply}, reply)
+		}
+		return actionResultMsg(text)
+	}
+}
+
+func (m *model) sendMessageCmd(value string) tea.Cmd {
+	root, enginePID, token := m.root, m.enginePID, m.sessionToken
+	receiverID, content := splitReceiverAndContent(value)
+	return func() tea.Msg {
+		reply := make(chan string, 1)
+		text := engineRequest(root, enginePID, &engine.SendMessage{SessionToken: token, ReceiverID: receiverID, Content: content, Reply: reply}, reply)
+		return actionResultMsg(text)
+	}
+}
+
+// splitReceiverAndContent parses the "m" compose overlay's "<receiverID>
+// <content>" shorthand.
+func splitReceiverAndContent(value string) (int, string) {
+	for i, r := range value {
+		if r == ' ' {
+			receiverID, _ := strconv.Atoi(value[:i])
+			return receiverID, value[i+1:]
+		}
+	}
+	receiverID, _ := strconv.Atoi(value)
+	return receiverID, ""
+}
+
+func (m *model) viewSubredditCmd(name string) tea.Cmd {
+	root, enginePID := m.root, m.enginePID
+	return func() tea.Msg {
+		reply := make(chan *engine.Subreddit, 1)
+		root.Send(enginePID, &engine.ViewSubreddit{Subreddit: name, Reply: reply})
+		return subredditLoadedMsg{subreddit: <-reply}
+	}
+}
+
+func (m *model) rankFeedCmd(subreddit string, mode engine.RankMode) tea.Cmd {
+	root, enginePID := m.root, m.enginePID
+	return func() tea.Msg {
+		reply := make(chan []*engine.Post, 1)
+		root.Send(enginePID, &engine.RankFeed{Subreddit: subreddit, Mode: mode, Reply: reply})
+		return rankedPostsMsg(<-reply)
+	}
+}
+
+func (m *model) listSubredditsCmd() tea.Cmd {
+	root, enginePID := m.root, m.enginePID
+	return func() tea.Msg {
+		reply := make(chan []string, 1)
+		root.Send(enginePID, &engine.ListSubreddits{Reply: reply})
+		return subredditsMsg(<-reply)
+	}
+}
+
+// logoutCmd revokes the session being switched away from rather than
+// leaving it to expire on its own after SessionTTL.
+func (m *model) logoutCmd() tea.Cmd {
+	root, enginePID, token := m.root, m.enginePID, m.sessionToken
+	if token == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		reply := make(chan string, 1)
+		engineRequest(root, enginePID, &engine.Logout{SessionToken: token, Reply: reply}, reply)
+		return actionResultMsg("")
+	}
+}
+
+func (m *model) viewInboxCmd() tea.Cmd {
+	root, enginePID, token := m.root, m.enginePID, m.sessionToken
+	return func() tea.Msg {
+		reply := make(chan string, 1)
+		text := engineRequest(root, enginePID, &engine.ViewInbox{SessionToken: token, Reply: reply}, reply)
+		return inboxMsg([]string{text})
+	}
+}
+
+// Bubble Tea messages produced by engine round trips.
+type actionResultMsg string
+type inboxMsg []string
+type subredditsMsg []string
+type loginResultMsg struct {
+	username string
+	result   engine.LoginResult
+}
+type subredditLoadedMsg struct {
+	subreddit *engine.Subreddit
+}
+type rankedPostsMsg []*engine.Post
+
+var (
+	paneStyle       = lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Padding(0, 1)
+	activePaneStyle = lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Padding(0, 1).BorderForeground(lipgloss.Color("12"))
+	titleStyle      = lipgloss.NewStyle().Bold(true)
+	selectedStyle   = lipgloss.NewStyle().Reverse(true)
+	statusStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	errStyle        = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+)
+
+func (m *model) View() string {
+	switch m.stage {
+	case stageLogin:
+		return m.viewLogin()
+	case stageInbox:
+		return m.viewInbox()
+	default:
+		return m.viewBrowse()
+	}
+}
+
+func (m *model) viewLogin() string {
+	accounts := ""
+	for i, a := range m.accounts {
+		accounts += fmt.Sprintf("  [%d] %s (id %d)\n", i+1, a.username, a.userID)
+	}
+	if accounts != "" {
+		accounts = titleStyle.Render("Recent accounts (press number to switch)") + "\n" + accounts + "\n"
+	}
+
+	return fmt.Sprintf(
+		"%s\n\n%susername: %s\npassword: %s\n\n%s",
+		titleStyle.Render("secure-reddit-clone — log in"),
+		accounts,
+		m.usernameInput.View(),
+		m.passwordInput.View(),
+		m.footer(),
+	)
+}
+
+func (m *model) paneWidth() int {
+	if m.width <= 0 {
+		return 30
+	}
+	return m.width/3 - 4
+}
+
+func (m *model) viewBrowse() string {
+	subStyle, postStyle, commentStyle := paneStyle, paneStyle, paneStyle
+	switch m.activePane {
+	case paneSubreddits:
+		subStyle = activePaneStyle
+	case panePosts:
+		postStyle = activePaneStyle
+	case paneComments:
+		commentStyle = activePaneStyle
+	}
+
+	width := m.paneWidth()
+	subPane := subStyle.Width(width).Render(m.renderSubreddits())
+	postPane := postStyle.Width(width).Render(m.renderPosts())
+	commentPane := commentStyle.Width(width).Render(m.renderComments())
+
+	body := lipgloss.JoinHorizontal(lipgloss.Top, subPane, postPane, commentPane)
+	return fmt.Sprintf("%s\n%s\n%s\n%s", m.header(), body, m.composeLine(), m.footer())
+}
+
+func (m *model) header() string {
+	return titleStyle.Render(fmt.Sprintf("logged in as %s (id %d)", m.username, m.userID))
+}
+
+func (m *model) renderSubreddits() string {
+	out := titleStyle.Render("Subreddits") + "\n"
+	if len(m.subreddits) == 0 {
+		return out + "(press 's' to create one)"
+	}
+	for i, name := range m.subreddits {
+		line := name
+		if i == m.subredditCursor {
+			line = selectedStyle.Render(line)
+		}
+		out += line + "\n"
+	}
+	return out
+}
+
+func (m *model) renderPosts() string {
+	out := titleStyle.Render(fmt.Sprintf("Posts (%s)", m.rankMode)) + "\n"
+	if len(m.posts) == 0 {
+		return out + "(select a subreddit)"
+	}
+	for i, post := range m.posts {
+		line := fmt.Sprintf("#%d [+%d/-%d] %s", post.ID, post.Upvotes, post.Downvotes, post.Content)
+		if i == m.postCursor {
+			line = selectedStyle.Render(line)
+		}
+		out += line + "\n"
+	}
+	return out
+}
+
+func (m *model) renderComments() string {
+	out := titleStyle.Render("Comments") + "\n"
+	post := m.selectedPost()
+	if post == nil || len(post.Comments) == 0 {
+		return out + "(no comments)"
+	}
+	for i, comment := range post.Comments {
+		line := fmt.Sprintf("%d. %s", i+1, comment)
+		if i == m.commentCursor {
+			line = selectedStyle.Render(line)
+		}
+		out += line + "\n"
+	}
+	return out
+}
+
+func (m *model) viewInbox() string {
+	out := titleStyle.Render("Inbox") + "\n"
+	for _, msg := range m.inbox {
+		out += msg + "\n"
+	}
+	return out + "\n" + m.footer()
+}
+
+func (m *model) composeLine() string {
+	if m.composing == composeNone {
+		return ""
+	}
+	return m.composeInput.View()
+}
+
+func (m *model) footer() string {
+	line := "tab: switch pane  enter: open  s: new sub  n: new post  c: comment  r: reply  g/b: like/dislike  J/x: join/leave  o: sort  m: message  i: inbox  a: switch account  q: quit"
+	if m.stage == stageLogin {
+		line = "tab: switch field  enter: log in  ctrl+n: register  1-9: switch cached account"
+	}
+	if m.err != "" {
+		return errStyle.Render(m.err) + "\n" + line
+	}
+	if m.status != "" {
+		return statusStyle.Render(m.status) + "\n" + line
+	}
+	return line
+}